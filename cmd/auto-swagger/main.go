@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Larry-Baltodano/go-auto-swagger/internal"
@@ -20,6 +22,8 @@ func main() {
 	outputFile := "openapi.json"
 	title := "Auto-Generated API"
 	version := "1.0.0"
+	format := ""
+	config := generator.DefaultConfig()
 
 	// Parsear argumentos opcionales
 	for i := 2; i < len(os.Args); i++ {
@@ -39,37 +43,121 @@ func main() {
 				version = os.Args[i+1]
 				i++
 			}
+		case "-f", "--format":
+			if i+1 < len(os.Args) {
+				format = os.Args[i+1]
+				i++
+			}
+		case "--openapi-version":
+			if i+1 < len(os.Args) {
+				config.OpenAPIVersion = os.Args[i+1]
+				i++
+			}
+		case "--generic-naming":
+			if i+1 < len(os.Args) {
+				config.GenericNaming = os.Args[i+1]
+				i++
+			}
+		case "--component-naming":
+			if i+1 < len(os.Args) {
+				config.ComponentNaming = os.Args[i+1]
+				i++
+			}
+		case "--default-responses":
+			if i+1 < len(os.Args) {
+				config.DefaultResponses = nil
+				for _, code := range strings.Split(os.Args[i+1], ",") {
+					code = strings.TrimSpace(code)
+					if code == "" || code == "none" {
+						continue
+					}
+					statusCode, err := strconv.Atoi(code)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "❌ Invalid options: invalid status code %q in --default-responses\n", code)
+						os.Exit(1)
+					}
+					config.DefaultResponses = append(config.DefaultResponses, statusCode)
+				}
+				i++
+			}
+		case "--error-type":
+			if i+1 < len(os.Args) {
+				config.ErrorType = os.Args[i+1]
+				i++
+			}
+		case "--operation-id":
+			if i+1 < len(os.Args) {
+				key, id, ok := strings.Cut(os.Args[i+1], "=")
+				if !ok {
+					fmt.Fprintf(os.Stderr, "❌ Invalid options: --operation-id expects ROUTE=ID or HANDLER=ID, got %q\n", os.Args[i+1])
+					os.Exit(1)
+				}
+				if config.OperationIDs == nil {
+					config.OperationIDs = make(map[string]string)
+				}
+				config.OperationIDs[strings.TrimSpace(key)] = strings.TrimSpace(id)
+				i++
+			}
+		case "--scope-middleware":
+			if i+1 < len(os.Args) {
+				config.ScopeMiddleware = append(config.ScopeMiddleware, splitList(os.Args[i+1])...)
+				i++
+			}
+		case "--role-middleware":
+			if i+1 < len(os.Args) {
+				config.RoleMiddleware = append(config.RoleMiddleware, splitList(os.Args[i+1])...)
+				i++
+			}
+		case "--oauth2-authorization-url":
+			if i+1 < len(os.Args) {
+				config.OAuth2AuthorizationURL = os.Args[i+1]
+				i++
+			}
+		case "--oauth2-token-url":
+			if i+1 < len(os.Args) {
+				config.OAuth2TokenURL = os.Args[i+1]
+				i++
+			}
 		case "-h", "--help":
 			printUsage()
 			os.Exit(0)
 		}
 	}
 
-	// Asegurar extensión .json
-	if !strings.HasSuffix(outputFile, ".json") {
-		outputFile += ".json"
+	if err := config.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Invalid options: %v\n", err)
+		os.Exit(1)
 	}
 
-	fmt.Printf("🔍 Auto-Swagger analyzing: %s\n", sourceDir)
-	fmt.Printf("📄 Output: %s\n", outputFile)
-	fmt.Printf("📝 Title: %s\n", title)
-	fmt.Printf("🔢 Version: %s\n\n", version)
+	// El formato se toma de --format o, si no se indica, de la extensión del archivo
+	if format == "" {
+		format = generator.FormatFromFilename(outputFile)
+	}
+	if format != generator.FormatJSON && format != generator.FormatYAML {
+		fmt.Fprintf(os.Stderr, "❌ Invalid options: unknown output format %q (use json or yaml)\n", format)
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "🔍 Auto-Swagger analyzing: %s\n", sourceDir)
+	fmt.Fprintf(os.Stderr, "📄 Output: %s (%s)\n", outputFile, format)
+	fmt.Fprintf(os.Stderr, "📝 Title: %s\n", title)
+	fmt.Fprintf(os.Stderr, "🔢 Version: %s\n\n", version)
 
 	// Analizar API completa
 	coordinator := internal.NewEnhancedCoordinator()
 	apiDesc, err := coordinator.AnalyzeAPI(sourceDir)
 	if err != nil {
-		fmt.Printf("❌ Error analyzing API: %v\n", err)
+		fmt.Fprintf(os.Stderr, "❌ Error analyzing API: %v\n", err)
 		os.Exit(1)
 	}
 
 	if len(apiDesc.Routes) == 0 {
-		fmt.Println("❌ No Gin routes found!")
+		fmt.Fprintln(os.Stderr, "❌ No Gin routes found!")
 		os.Exit(1)
 	}
 
 	// Mostrar resumen del análisis
-	fmt.Printf("✅ Found %d routes\n", len(apiDesc.Routes))
+	fmt.Fprintf(os.Stderr, "✅ Found %d routes\n", len(apiDesc.Routes))
 	
 	handlersAnalyzed := 0
 	for _, route := range apiDesc.Routes {
@@ -77,60 +165,142 @@ func main() {
 			handlersAnalyzed++
 		}
 	}
-	fmt.Printf("🔧 Handlers analyzed: %d\n", handlersAnalyzed)
+	fmt.Fprintf(os.Stderr, "🔧 Handlers analyzed: %d\n", handlersAnalyzed)
 
 	// Generar OpenAPI spec
-	fmt.Println("\n🚀 Generating OpenAPI specification...")
-	
-	openapiGenerator := generator.NewOpenAPIGenerator(coordinator)
+	fmt.Fprintln(os.Stderr, "\n🚀 Generating OpenAPI specification...")
 	
-	// Guardar archivo
-	if err := openapiGenerator.SaveToFile(apiDesc, outputFile, title, version); err != nil {
-		fmt.Printf("❌ Error generating OpenAPI: %v\n", err)
+	var openapiGenerator specGenerator
+	if config.OpenAPIVersion == generator.Swagger20 {
+		swaggerGenerator := generator.NewSwagger2Generator(coordinator)
+		swaggerGenerator.Config = config
+		openapiGenerator = swaggerGenerator
+	} else {
+		openAPIGenerator := generator.NewOpenAPIGenerator(coordinator)
+		openAPIGenerator.Config = config
+		openapiGenerator = openAPIGenerator
+	}
+
+	specData, err := openapiGenerator.GenerateJSON(apiDesc, title, version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error generating OpenAPI: %v\n", err)
+		os.Exit(1)
+	}
+
+	output, err := generator.EncodeSpec(specData, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error generating OpenAPI: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Guardar archivo; "-o -" escribe la especificación en stdout
+	if outputFile == "-" {
+		_, err = os.Stdout.Write(output)
+	} else {
+		err = os.WriteFile(outputFile, output, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error writing OpenAPI: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Mostrar información del spec generado
-	specData, _ := openapiGenerator.GenerateJSON(apiDesc, title, version)
 	var spec map[string]interface{}
 	json.Unmarshal(specData, &spec)
 
-	fmt.Printf("🎉 OpenAPI specification generated successfully!\n")
-	fmt.Printf("📊 Generated Spec:\n")
-	fmt.Printf("   • Paths: %d\n", len(spec["paths"].(map[string]interface{})))
+	for _, diagnostic := range openapiGenerator.Diagnostics() {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", diagnostic)
+	}
+
+	renames := openapiGenerator.Renames()
+	if len(renames) > 0 {
+		fmt.Fprintf(os.Stderr, "🔀 Renamed components (name used by several packages):\n")
+		typeNames := make([]string, 0, len(renames))
+		for typeName := range renames {
+			typeNames = append(typeNames, typeName)
+		}
+		sort.Strings(typeNames)
+		for _, typeName := range typeNames {
+			fmt.Fprintf(os.Stderr, "   • %s → %s\n", typeName, renames[typeName])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "🎉 OpenAPI specification generated successfully!\n")
+	fmt.Fprintf(os.Stderr, "📊 Generated Spec:\n")
+	fmt.Fprintf(os.Stderr, "   • Paths: %d\n", len(spec["paths"].(map[string]interface{})))
 	
 	if components, exists := spec["components"]; exists {
 		if schemas, exists := components.(map[string]interface{})["schemas"]; exists {
-			fmt.Printf("   • Schemas: %d\n", len(schemas.(map[string]interface{})))
+			fmt.Fprintf(os.Stderr, "   • Schemas: %d\n", len(schemas.(map[string]interface{})))
 		}
 	}
+	if definitions, exists := spec["definitions"]; exists {
+		fmt.Fprintf(os.Stderr, "   • Definitions: %d\n", len(definitions.(map[string]interface{})))
+	}
 
 	// Mostrar endpoints generados
-	fmt.Printf("\n📋 Generated Endpoints:\n")
+	fmt.Fprintf(os.Stderr, "\n📋 Generated Endpoints:\n")
 	paths := spec["paths"].(map[string]interface{})
 	for path, pathItem := range paths {
 		operations := pathItem.(map[string]interface{})
 		for method := range operations {
-			fmt.Printf("   • %s %s\n", strings.ToUpper(method), path)
+			fmt.Fprintf(os.Stderr, "   • %s %s\n", strings.ToUpper(method), path)
 		}
 	}
 
-	fmt.Printf("\n📁 File: %s\n", outputFile)
-	fmt.Printf("🎯 OpenAPI 3.0.3 compliant\n")
-	fmt.Printf("🚀 You can now use this with Swagger UI or other OpenAPI tools\n")
+	if outputFile != "-" {
+		fmt.Fprintf(os.Stderr, "\n📁 File: %s\n", outputFile)
+	}
+	if config.OpenAPIVersion == generator.Swagger20 {
+		fmt.Fprintf(os.Stderr, "🎯 Swagger %s compliant\n", config.OpenAPIVersion)
+	} else {
+		fmt.Fprintf(os.Stderr, "🎯 OpenAPI %s compliant\n", config.OpenAPIVersion)
+	}
+	fmt.Fprintf(os.Stderr, "🚀 You can now use this with Swagger UI or other OpenAPI tools\n")
+}
+
+// specGenerator es la interfaz común de los generadores OpenAPI 3.x y Swagger 2.0
+type specGenerator interface {
+	GenerateJSON(apiDesc *internal.APIDescription, title, version string) ([]byte, error)
+	Diagnostics() []string
+	Renames() map[string]string
 }
 
 func printUsage() {
 	fmt.Println("Usage: auto-swagger <source-directory> [options]")
 	fmt.Println()
 	fmt.Println("Options:")
-	fmt.Println("  -o, --output FILE    Output file (default: openapi.json)")
+	fmt.Println("  -o, --output FILE    Output file, or - for stdout (default: openapi.json)")
+	fmt.Println("  -f, --format F       Output format: json or yaml (default: from file extension)")
 	fmt.Println("  -t, --title TITLE    API title (default: 'Auto-Generated API')")
 	fmt.Println("  -v, --version VER    API version (default: '1.0.0')")
+	fmt.Println("  --openapi-version V  OpenAPI version: 3.0.3, 3.1.0 or 2.0 (default: 3.0.3)")
+	fmt.Println("  --generic-naming S   Generic component names: underscore, of, concat (default: underscore)")
+	fmt.Println("  --component-naming T Template for colliding component names (default: '{package}.{name}')")
+	fmt.Println("  --default-responses L Error codes documented on every operation, or 'none' (default: 500)")
+	fmt.Println("  --error-type TYPE    Error envelope struct for error responses (default: detected)")
+	fmt.Println("  --operation-id K=ID  Override an operationId; K is 'GET /users/{id}' or a handler (repeatable)")
+	fmt.Println("  --scope-middleware L Middleware constructors whose arguments are OAuth2 scopes (e.g. RequireScopes)")
+	fmt.Println("  --role-middleware L  Middleware constructors whose arguments are roles, documented as x-roles")
+	fmt.Println("  --oauth2-authorization-url URL, --oauth2-token-url URL")
+	fmt.Println("                       Endpoints of the oauth2 scheme (default: /oauth/authorize, /oauth/token)")
 	fmt.Println("  -h, --help           Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  auto-swagger ./examples/basic-app")
 	fmt.Println("  auto-swagger . -o myapi.json -t \"My API\" -v 2.0.0")
 	fmt.Println("  auto-swagger ./internal/api --output docs/openapi.json")
-}
\ No newline at end of file
+	fmt.Println("  auto-swagger . -o openapi.yaml")
+	fmt.Println("  auto-swagger . -o - --format yaml > openapi.yaml")
+}
+
+// splitList separa una lista de valores por comas, descartando los vacíos
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -18,6 +18,21 @@ type RouteDescription struct {
 	Handler     string
 	HandlerInfo *handler.HandlerInfo
 	File        string
+
+	// Security tiene una entrada por middleware de autenticación de la ruta con los
+	// esquemas que acepta; todos los middlewares se exigen a la vez
+	Security [][]handler.SecurityInfo
+
+	// Middleware son los middlewares de la ruta en orden de ejecución
+	Middleware []MiddlewareDescription
+}
+
+// MiddlewareDescription describe un middleware de la ruta y los argumentos constantes
+// de su constructor (RequireScopes("users:write") → ["users:write"])
+type MiddlewareDescription struct {
+	Name      string
+	Arguments []string
+	Headers   []handler.HeaderInfo // Headers de respuesta que fija el middleware
 }
 
 type Coordinator struct {
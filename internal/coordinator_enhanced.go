@@ -38,25 +38,40 @@ func (c *EnhancedCoordinator) AnalyzeAPI(sourceDir string) (*APIDescription, err
 			File:    route.File,
 		}
 
-		handlerInfo, err := c.analyzeHandlerEnhanced(route.Handler, route.File)
+		handlerInfo, err := c.analyzeHandlerEnhanced(route.Handler, route.File, route.Line)
 		if err == nil && handlerInfo != nil {
 			routeDesc.HandlerInfo = handlerInfo
 		}
 
+		for _, middleware := range route.Middleware {
+			routeDesc.Middleware = append(routeDesc.Middleware, MiddlewareDescription{
+				Name:      middleware.Name,
+				Arguments: c.HandlerAnalyzer.MiddlewareArguments(middleware.File, middleware.Line, middleware.Column),
+				Headers:   c.HandlerAnalyzer.MiddlewareHeaders(middleware.File, middleware.Line, middleware.Column),
+			})
+			if schemes := c.HandlerAnalyzer.AnalyzeMiddleware(middleware.File, middleware.Line, middleware.Column); len(schemes) > 0 {
+				routeDesc.Security = append(routeDesc.Security, schemes)
+			}
+		}
+
 		apiDesc.Routes = append(apiDesc.Routes, routeDesc)
 	}
 
 	return apiDesc, nil
 }
 
-func (c *EnhancedCoordinator) analyzeHandlerEnhanced(handlerName, filePath string) (*handler.HandlerInfo, error) {
+func (c *EnhancedCoordinator) analyzeHandlerEnhanced(handlerName, filePath string, line int) (*handler.HandlerInfo, error) {
 	packageName := filepath.Base(filepath.Dir(filePath))
 	parts := strings.Split(handlerName, ".")
 	
 	if len(parts) == 1 {
 		return c.HandlerAnalyzer.AnalyzeFunction(packageName, filePath, parts[0])
 	} else if len(parts) == 2 {
-		structName := c.inferStructName(parts[0])
+		// Preferir el tipo real de la variable; el nombre inferido es el último recurso
+		structName := c.HandlerAnalyzer.ResolveVariableType(filePath, line, parts[0])
+		if structName == "" {
+			structName = c.inferStructName(parts[0])
+		}
 		return c.HandlerAnalyzer.AnalyzeMethod(packageName, filePath, structName, parts[1])
 	}
 
@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+// nameMode indica cuánto se califica el nombre de un componente
+type nameMode int
+
+const (
+	nameShort     nameMode = iota // User
+	nameQualified                 // Plantilla de configuración: admin.User
+	namePath                      // Ruta de importación completa: example.com.app.admin.User
+)
+
+// ResolveNames asigna el nombre final de cada componente de forma determinista:
+// el nombre corto cuando es único y uno calificado por paquete cuando no lo es.
+// Las instancias genéricas se nombran después de sus argumentos, con el nombre final
+// de cada uno. Devuelve la correspondencia de referencias provisionales a referencias finales.
+func (b *SchemaBuilder) ResolveNames() map[string]string {
+	levels := make(map[int][]string)
+	for key, named := range b.types {
+		depth := genericDepth(named)
+		levels[depth] = append(levels[depth], key)
+	}
+
+	depths := make([]int, 0, len(levels))
+	for depth := range levels {
+		depths = append(depths, depth)
+	}
+	sort.Ints(depths)
+
+	used := make(map[string]bool)
+	for _, depth := range depths {
+		b.resolveLevel(levels[depth], used)
+	}
+
+	refs := make(map[string]string, len(b.names))
+	for key, name := range b.names {
+		refs[componentsPrefix+key] = componentsPrefix + name
+		if short := b.displayName(b.types[key], nameShort); name != short {
+			b.renames[key] = name
+		}
+	}
+	return refs
+}
+
+// resolveLevel nombra los componentes de un mismo nivel de anidamiento genérico
+func (b *SchemaBuilder) resolveLevel(keys []string, used map[string]bool) {
+	pending := append([]string(nil), keys...)
+	sort.Strings(pending)
+
+	for _, mode := range []nameMode{nameShort, nameQualified, namePath} {
+		groups := make(map[string][]string)
+		for _, key := range pending {
+			name := b.displayName(b.types[key], mode)
+			groups[name] = append(groups[name], key)
+		}
+
+		pending = pending[:0]
+		for _, name := range sortedKeys(groups) {
+			group := groups[name]
+			if len(group) == 1 && !used[name] {
+				b.names[group[0]] = name
+				used[name] = true
+				continue
+			}
+			pending = append(pending, group...)
+		}
+		sort.Strings(pending)
+	}
+
+	// Nombres idénticos incluso con la ruta completa: sufijo numérico estable
+	for i, key := range pending {
+		name := fmt.Sprintf("%s_%d", b.displayName(b.types[key], namePath), i+1)
+		b.names[key] = name
+		used[name] = true
+	}
+}
+
+// Renames devuelve los componentes que no pudieron usar su nombre corto
+func (b *SchemaBuilder) Renames() map[string]string {
+	return b.renames
+}
+
+// displayName construye el nombre de un tipo; las instancias genéricas incluyen sus argumentos
+func (b *SchemaBuilder) displayName(named *types.Named, mode nameMode) string {
+	name := named.Obj().Name()
+	if pkg := named.Obj().Pkg(); pkg != nil {
+		switch mode {
+		case nameQualified:
+			name = applyComponentTemplate(b.componentNaming, pkg.Name(), name)
+		case namePath:
+			name = strings.ReplaceAll(pkg.Path(), "/", ".") + "." + name
+		}
+	}
+
+	if named.TypeArgs().Len() == 0 {
+		return name
+	}
+
+	args := make([]string, 0, named.TypeArgs().Len())
+	for i := 0; i < named.TypeArgs().Len(); i++ {
+		args = append(args, b.typeArgName(named.TypeArgs().At(i), mode))
+	}
+	return b.genericNaming(name, args)
+}
+
+// typeArgName obtiene un nombre estable para un argumento de tipo
+func (b *SchemaBuilder) typeArgName(t types.Type, mode nameMode) string {
+	switch t := t.(type) {
+	case *types.Alias:
+		return b.typeArgName(types.Unalias(t), mode)
+	case *types.Named:
+		if name, resolved := b.names[types.TypeString(t, nil)]; resolved {
+			return name // Componente ya nombrado: se usa su nombre final
+		}
+		return b.displayName(t, mode)
+	case *types.Pointer:
+		return b.typeArgName(t.Elem(), mode)
+	case *types.Slice:
+		return b.typeArgName(t.Elem(), mode) + "List"
+	case *types.Array:
+		return b.typeArgName(t.Elem(), mode) + "List"
+	case *types.Map:
+		return b.typeArgName(t.Elem(), mode) + "Map"
+	case *types.Basic:
+		return types.Default(t).(*types.Basic).Name()
+	default:
+		return "Object"
+	}
+}
+
+// genericDepth indica cuántos niveles de instancias genéricas anida un tipo:
+// 0 para User, 1 para Page[User] y 2 para Response[Page[User]]
+func genericDepth(named *types.Named) int {
+	depth := 0
+	args := named.TypeArgs()
+	for i := 0; i < args.Len(); i++ {
+		depth = max(depth, 1+typeArgDepth(args.At(i)))
+	}
+	return depth
+}
+
+// typeArgDepth es el anidamiento genérico de un argumento de tipo ([]Page[User] → 1)
+func typeArgDepth(t types.Type) int {
+	switch t := t.(type) {
+	case *types.Alias:
+		return typeArgDepth(types.Unalias(t))
+	case *types.Named:
+		return genericDepth(t)
+	case *types.Pointer:
+		return typeArgDepth(t.Elem())
+	case *types.Slice:
+		return typeArgDepth(t.Elem())
+	case *types.Array:
+		return typeArgDepth(t.Elem())
+	case *types.Map:
+		return typeArgDepth(t.Elem())
+	}
+	return 0
+}
+
+// applyComponentTemplate sustituye {package}, {Package} y {name} en la plantilla
+func applyComponentTemplate(template, packageName, name string) string {
+	return strings.NewReplacer(
+		"{package}", packageName,
+		"{Package}", strings.ToUpper(packageName[:1])+packageName[1:],
+		"{name}", name,
+	).Replace(template)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
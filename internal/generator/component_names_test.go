@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestComponentNameCollisions(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"user/user.go": `package user
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Profile struct {
+	Bio string ` + "`json:\"bio\"`" + `
+}
+`,
+		"admin/admin.go": `package admin
+
+type User struct {
+	Role string ` + "`json:\"role\"`" + `
+}
+`,
+		"main.go": `package main
+
+import (
+	"example.com/app/admin"
+	"example.com/app/user"
+	"github.com/gin-gonic/gin"
+)
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, user.User{})
+}
+
+func GetAdmin(c *gin.Context) {
+	c.JSON(200, admin.User{})
+}
+
+func GetProfile(c *gin.Context) {
+	c.JSON(200, user.Profile{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users", GetUser)
+	r.GET("/admins", GetAdmin)
+	r.GET("/profile", GetProfile)
+}
+`,
+	}
+
+	tempDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	for run := 0; run < 2; run++ {
+		coordinator := internal.NewEnhancedCoordinator()
+		apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+		if err != nil {
+			t.Fatalf("AnalyzeAPI failed: %v", err)
+		}
+
+		openapiGenerator := NewOpenAPIGenerator(coordinator)
+		spec := openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+
+		for _, name := range []string{"user.User", "admin.User", "Profile"} {
+			if _, exists := spec.Components.Schemas[name]; !exists {
+				t.Errorf("Expected component %s, got %v", name, spec.Components.Schemas)
+			}
+		}
+
+		ref := spec.Paths["/admins"].Get.Responses["200"].Content["application/json"].Schema.Ref
+		if ref != "#/components/schemas/admin.User" {
+			t.Errorf("Expected /admins to reference admin.User, got %q", ref)
+		}
+
+		renames := openapiGenerator.Renames()
+		if len(renames) != 2 || renames["example.com/app/admin.User"] != "admin.User" {
+			t.Errorf("Expected renames for both User types, got %v", renames)
+		}
+	}
+
+	builder := NewSchemaBuilder(Config{ComponentNaming: "{Package}{name}"}, nil)
+	if name := applyComponentTemplate(builder.componentNaming, "admin", "User"); name != "AdminUser" {
+		t.Errorf("Expected AdminUser from template, got %s", name)
+	}
+}
+
+func TestGenericNamesUseResolvedArguments(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"admin/admin.go": `package admin
+
+type User struct {
+	Role string ` + "`json:\"role\"`" + `
+}
+`,
+		"main.go": `package main
+
+import (
+	"example.com/app/admin"
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Page[T any] struct {
+	Items []T ` + "`json:\"items\"`" + `
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, Page[User]{})
+}
+
+func ListAdmins(c *gin.Context) {
+	c.JSON(200, Page[admin.User]{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users", ListUsers)
+	r.GET("/admins", ListAdmins)
+}
+`,
+	}
+
+	tempDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	spec := NewOpenAPIGenerator(coordinator).Generate(apiDesc, "Test API", "1.0.0")
+
+	// Cada Page toma el nombre final de su argumento, que ya se desambiguó por paquete
+	expected := map[string]string{
+		"/users":  "Page_main.User",
+		"/admins": "Page_admin.User",
+	}
+	for path, name := range expected {
+		ref := spec.Paths[path].Get.Responses["200"].Content["application/json"].Schema.Ref
+		if ref != componentsPrefix+name {
+			t.Errorf("Expected %s to reference %s, got %q", path, name, ref)
+		}
+
+		page, exists := spec.Components.Schemas[name]
+		if !exists {
+			t.Fatalf("Expected component %s, got %v", name, spec.Components.Schemas)
+		}
+		argument := strings.TrimPrefix(name, "Page_")
+		if items := page.Properties["items"].Items; items == nil || items.Ref != componentsPrefix+argument {
+			t.Errorf("Expected %s.items to reference %s, got %+v", name, argument, items)
+		}
+	}
+}
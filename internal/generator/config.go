@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Versiones de OpenAPI soportadas
+const (
+	OpenAPI30 = "3.0.3"
+	OpenAPI31 = "3.1.0"
+	Swagger20 = "2.0"
+)
+
+// Config agrupa las opciones de generación de la especificación
+type Config struct {
+	// OpenAPIVersion selecciona la versión de salida (3.0.3, 3.1.0 o 2.0 para Swagger)
+	OpenAPIVersion string
+
+	// GenericNaming selecciona cómo se nombran los tipos genéricos instanciados
+	// (underscore: Page_User, of: PageOfUser, concat: PageUser)
+	GenericNaming string
+
+	// ComponentNaming es la plantilla para nombres de componentes que colisionan
+	// entre paquetes; admite {package}, {Package} y {name}
+	ComponentNaming string
+
+	// OperationIDs fija el operationId de operaciones concretas; la clave es la ruta
+	// ("GET /users/{id}") o el handler ("APIHandler.GetUserByID")
+	OperationIDs map[string]string
+
+	// DefaultResponses son los códigos de error que se documentan en todas las operaciones
+	// además de los que escribe el handler; se declaran una vez en components.responses
+	DefaultResponses []int
+
+	// ErrorType es el struct de error del proyecto (ErrorResponse, api.ErrorResponse o la
+	// ruta completa); si está vacío se detecta a partir de las respuestas de error
+	ErrorType string
+
+	// ScopeMiddleware son los constructores de middleware cuyos argumentos son scopes
+	// OAuth2 (RequireScopes o auth.RequireScopes); se documentan en security
+	ScopeMiddleware []string
+
+	// RoleMiddleware son los constructores de middleware cuyos argumentos son roles
+	// (RequireRole); se documentan en la extensión x-roles de la operación
+	RoleMiddleware []string
+
+	// OAuth2AuthorizationURL y OAuth2TokenURL son los endpoints del esquema oauth2; el
+	// flujo es authorizationCode con ambos, implicit o clientCredentials con uno solo
+	OAuth2AuthorizationURL string
+	OAuth2TokenURL         string
+}
+
+// DefaultConfig devuelve la configuración por defecto
+func DefaultConfig() Config {
+	return Config{
+		OpenAPIVersion:  OpenAPI30,
+		GenericNaming:   "underscore",
+		ComponentNaming: "{package}.{name}",
+		// gin.Recovery responde 500 ante cualquier panic
+		DefaultResponses:       []int{http.StatusInternalServerError},
+		OAuth2AuthorizationURL: "/oauth/authorize",
+		OAuth2TokenURL:         "/oauth/token",
+	}
+}
+
+// GenericNaming construye el nombre de componente de un tipo genérico instanciado
+type GenericNaming func(base string, args []string) string
+
+// GenericNamingStrategies contiene las estrategias de nombres disponibles
+var GenericNamingStrategies = map[string]GenericNaming{
+	"underscore": func(base string, args []string) string {
+		return base + "_" + strings.Join(args, "_")
+	},
+	"of": func(base string, args []string) string {
+		return base + "Of" + strings.Join(args, "And")
+	},
+	"concat": func(base string, args []string) string {
+		return base + strings.Join(args, "")
+	},
+}
+
+// Validate verifica que las opciones tengan valores conocidos
+func (c Config) Validate() error {
+	if c.OpenAPIVersion != OpenAPI30 && c.OpenAPIVersion != OpenAPI31 && c.OpenAPIVersion != Swagger20 {
+		return fmt.Errorf("unsupported OpenAPI version %q (use %s, %s or %s)", c.OpenAPIVersion, OpenAPI30, OpenAPI31, Swagger20)
+	}
+	if _, exists := GenericNamingStrategies[c.GenericNaming]; !exists {
+		return fmt.Errorf("unknown generic naming strategy %q", c.GenericNaming)
+	}
+	if !strings.Contains(c.ComponentNaming, "{name}") {
+		return fmt.Errorf("component naming template %q must contain {name}", c.ComponentNaming)
+	}
+
+	for _, statusCode := range c.DefaultResponses {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("invalid default response status code %d", statusCode)
+		}
+	}
+
+	for _, name := range c.ScopeMiddleware {
+		for _, role := range c.RoleMiddleware {
+			if name == role {
+				return fmt.Errorf("middleware %q declared for both scopes and roles", name)
+			}
+		}
+	}
+	if len(c.ScopeMiddleware) > 0 && c.OAuth2AuthorizationURL == "" && c.OAuth2TokenURL == "" {
+		return fmt.Errorf("scope middleware requires an OAuth2 authorization or token URL")
+	}
+
+	assigned := make(map[string]string)
+	for _, key := range sortedKeys(c.OperationIDs) {
+		id := c.OperationIDs[key]
+		if id == "" {
+			return fmt.Errorf("empty operationId for %q", key)
+		}
+		if previous, exists := assigned[id]; exists {
+			return fmt.Errorf("operationId %q assigned to both %q and %q", id, previous, key)
+		}
+		assigned[id] = key
+	}
+	return nil
+}
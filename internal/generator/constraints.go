@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tagFormats son las validaciones de go-playground/validator que equivalen a un format
+var tagFormats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid4":    "uuid",
+	"url":      "uri",
+	"uri":      "uri",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+}
+
+// tagPatterns son las validaciones que equivalen a una expresión regular
+var tagPatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"number":   "^[0-9]+$",
+}
+
+// applyTagConstraints traduce las reglas de los tags binding y validate a restricciones
+// del schema. Como en validator, min/max/len limitan el valor de los números, la longitud
+// de los textos y la cantidad de elementos de las listas.
+func applyTagConstraints(schema *Schema, tag reflect.StructTag) {
+	for _, key := range []string{"binding", "validate"} {
+		applyRules(schema, strings.Split(tag.Get(key), ","))
+	}
+}
+
+// applyRules aplica una lista de reglas de validator. Las reglas que siguen a dive
+// validan cada elemento de la lista o cada valor del mapa.
+func applyRules(schema *Schema, rules []string) {
+	for i, rule := range rules {
+		name, value, _ := strings.Cut(rule, "=")
+
+		if name == "dive" {
+			if element := diveTarget(schema); element != nil {
+				applyRules(element, skipKeyRules(rules[i+1:]))
+			}
+			return
+		}
+		if name == "datetime" {
+			if schema.Type == "string" && schema.Format == "" {
+				schema.Format = datetimeFormat(value)
+			}
+			continue
+		}
+		if format, exists := tagFormats[name]; exists && schema.Type == "string" && schema.Format == "" {
+			schema.Format = format
+			continue
+		}
+		if pattern, exists := tagPatterns[name]; exists && schema.Type == "string" {
+			schema.Pattern = pattern
+			continue
+		}
+
+		limit, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+
+		switch name {
+		case "min", "gte":
+			schema.setLowerBound(limit, false)
+		case "gt":
+			schema.setLowerBound(limit, true)
+		case "max", "lte":
+			schema.setUpperBound(limit, false)
+		case "lt":
+			schema.setUpperBound(limit, true)
+		case "len":
+			schema.setLowerBound(limit, false)
+			schema.setUpperBound(limit, false)
+		}
+	}
+}
+
+// diveTarget devuelve el schema de los elementos que valida dive (nil si no es una colección)
+func diveTarget(schema *Schema) *Schema {
+	switch schema.Type {
+	case "array":
+		return schema.Items
+	case "object":
+		return schema.AdditionalProperties
+	}
+	return nil
+}
+
+// skipKeyRules descarta el bloque keys...endkeys, que valida las claves de un mapa
+func skipKeyRules(rules []string) []string {
+	if len(rules) == 0 || rules[0] != "keys" {
+		return rules
+	}
+	for i, rule := range rules {
+		if rule == "endkeys" {
+			return rules[i+1:]
+		}
+	}
+	return nil
+}
+
+// datetimeFormat elige el format de datetime=<layout>: solo los layouts de fecha y de
+// fecha y hora RFC 3339 tienen equivalente en OpenAPI
+func datetimeFormat(layout string) string {
+	switch layout {
+	case time.DateOnly:
+		return "date"
+	case time.RFC3339, time.RFC3339Nano:
+		return "date-time"
+	}
+	return ""
+}
+
+// setLowerBound aplica un mínimo según el tipo: valor, longitud o cantidad de elementos
+func (s *Schema) setLowerBound(limit float64, exclusive bool) {
+	switch s.Type {
+	case "integer", "number":
+		s.Minimum = &limit
+		if exclusive {
+			s.ExclusiveMinimum = true
+		}
+	case "string":
+		length := int(limit)
+		if exclusive {
+			length++
+		}
+		s.MinLength = &length
+	case "array":
+		count := int(limit)
+		if exclusive {
+			count++
+		}
+		s.MinItems = &count
+	}
+}
+
+// setUpperBound aplica un máximo según el tipo: valor, longitud o cantidad de elementos
+func (s *Schema) setUpperBound(limit float64, exclusive bool) {
+	switch s.Type {
+	case "integer", "number":
+		s.Maximum = &limit
+		if exclusive {
+			s.ExclusiveMaximum = true
+		}
+	case "string":
+		length := int(limit)
+		if exclusive {
+			length--
+		}
+		s.MaxLength = &length
+	case "array":
+		count := int(limit)
+		if exclusive {
+			count--
+		}
+		s.MaxItems = &count
+	}
+}
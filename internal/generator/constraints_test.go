@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagConstraints(t *testing.T) {
+	intPtr := func(value int) *int { return &value }
+	floatPtr := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		schemaType string
+		tag        reflect.StructTag
+		expected   Schema
+	}{
+		{"integer", `binding:"min=1,max=100"`, Schema{Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(100)}},
+		{"number", `validate:"gt=0,lt=1"`, Schema{Type: "number", Minimum: floatPtr(0), ExclusiveMinimum: true, Maximum: floatPtr(1), ExclusiveMaximum: true}},
+		{"string", `binding:"required,min=3,max=20"`, Schema{Type: "string", MinLength: intPtr(3), MaxLength: intPtr(20)}},
+		{"string", `binding:"len=36,uuid"`, Schema{Type: "string", Format: "uuid", MinLength: intPtr(36), MaxLength: intPtr(36)}},
+		{"string", `validate:"email"`, Schema{Type: "string", Format: "email"}},
+		{"string", `binding:"alphanum"`, Schema{Type: "string", Pattern: "^[a-zA-Z0-9]+$"}},
+		{"string", `binding:"datetime=2006-01-02"`, Schema{Type: "string", Format: "date"}},
+		{"string", `binding:"datetime=2006-01-02T15:04:05Z07:00"`, Schema{Type: "string", Format: "date-time"}},
+		{"string", `binding:"datetime=15:04"`, Schema{Type: "string"}},
+		{"array", `binding:"min=1,max=10"`, Schema{Type: "array", MinItems: intPtr(1), MaxItems: intPtr(10)}},
+		{"boolean", `binding:"min=1"`, Schema{Type: "boolean"}},
+	}
+
+	for _, test := range tests {
+		schema := Schema{Type: test.schemaType}
+		applyTagConstraints(&schema, test.tag)
+		if !reflect.DeepEqual(schema, test.expected) {
+			t.Errorf("Expected %+v for %s %s, got %+v", test.expected, test.schemaType, test.tag, schema)
+		}
+	}
+}
+
+func TestDiveConstraints(t *testing.T) {
+	intPtr := func(value int) *int { return &value }
+
+	// Las reglas anteriores a dive limitan la lista; las posteriores, cada elemento
+	list := Schema{Type: "array", Items: &Schema{Type: "string"}}
+	applyTagConstraints(&list, `binding:"max=5,dive,min=1,email"`)
+	expected := Schema{Type: "array", MaxItems: intPtr(5), Items: &Schema{Type: "string", Format: "email", MinLength: intPtr(1)}}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, list)
+	}
+
+	// En los mapas dive valida los valores; keys...endkeys valida las claves
+	labels := Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}
+	applyTagConstraints(&labels, `validate:"dive,keys,alpha,endkeys,max=10"`)
+	expected = Schema{Type: "object", AdditionalProperties: &Schema{Type: "string", MaxLength: intPtr(10)}}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, labels)
+	}
+}
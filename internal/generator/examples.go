@@ -0,0 +1,259 @@
+package generator
+
+import (
+	"encoding/json"
+	"go/constant"
+	"go/types"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// placeholderExamples son los valores usados cuando un campo no declara ejemplo
+var placeholderExamples = map[string]interface{}{
+	"date-time": "2024-01-01T00:00:00Z",
+	"date":      "2024-01-01",
+	"email":     "user@example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"byte":      "U3dhZ2dlciByb2Nrcw==",
+}
+
+// applyTagExamples completa enum, default y example a partir de los tags del campo:
+// example:"..." tiene prioridad, luego el default (form:",default=10" o default:"10")
+// y por último el primer valor del enum
+func applyTagExamples(schema *Schema, tag reflect.StructTag) {
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(tag.Get(key), ",") {
+			if values, ok := strings.CutPrefix(rule, "oneof="); ok {
+				schema.Enum = nil
+				for _, value := range strings.Fields(values) {
+					schema.Enum = append(schema.Enum, parseExample(schema, value))
+				}
+			}
+		}
+	}
+
+	if value, ok := tagDefault(tag); ok {
+		schema.Default = parseExample(schema, value)
+	}
+
+	switch {
+	case tag.Get("example") != "":
+		schema.Example = parseExample(schema, tag.Get("example"))
+	case schema.Default != nil:
+		schema.Example = schema.Default
+	case len(schema.Enum) > 0:
+		schema.Example = schema.Enum[0]
+	}
+}
+
+// tagDefault obtiene el valor por defecto declarado para gin (form:"limit,default=10") o con default:"10"
+func tagDefault(tag reflect.StructTag) (string, bool) {
+	for _, option := range strings.Split(tag.Get("form"), ",")[1:] {
+		if value, ok := strings.CutPrefix(option, "default="); ok {
+			return value, true
+		}
+	}
+	return tag.Lookup("default")
+}
+
+// parseExample interpreta el texto del tag según el tipo del schema
+func parseExample(schema *Schema, value string) interface{} {
+	switch schema.Type {
+	case "integer":
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	case "number":
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	case "boolean":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	case "object":
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+			return parsed
+		}
+	case "array":
+		if schema.Items != nil {
+			items := []interface{}{}
+			for _, item := range strings.Split(value, ",") {
+				items = append(items, parseExample(schema.Items, strings.TrimSpace(item)))
+			}
+			return items
+		}
+	}
+	return value
+}
+
+// applyConstantEnum documenta como enum las constantes declaradas con un tipo con nombre
+// (type Status string; const StatusActive Status = "active")
+func (b *SchemaBuilder) applyConstantEnum(schema *Schema, named *types.Named) {
+	if b.source == nil {
+		return
+	}
+
+	for _, declared := range b.source.Constants(named) {
+		if value := constantValue(declared.Val()); value != nil {
+			schema.Enum = append(schema.Enum, value)
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		schema.Example = schema.Enum[0]
+	}
+}
+
+// constantValue convierte un valor constante de go/types en un valor JSON
+func constantValue(value constant.Value) interface{} {
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value)
+	case constant.Int:
+		if parsed, ok := constant.Int64Val(value); ok {
+			return parsed
+		}
+	case constant.Float:
+		parsed, _ := constant.Float64Val(value)
+		return parsed
+	case constant.Bool:
+		return constant.BoolVal(value)
+	}
+	return nil
+}
+
+// generateExamples construye un ejemplo completo para cada request body y respuesta
+func (g *OpenAPIGenerator) generateExamples(spec *OpenAPISpec) {
+	for _, pathItem := range spec.Paths {
+		for _, operation := range pathItem.operations() {
+			if operation.RequestBody != nil {
+				g.fillContentExamples(spec, operation.RequestBody.Content)
+			}
+			for _, response := range operation.Responses {
+				g.fillContentExamples(spec, response.Content)
+			}
+		}
+	}
+
+	for _, response := range spec.Components.Responses {
+		g.fillContentExamples(spec, response.Content)
+	}
+}
+
+func (g *OpenAPIGenerator) fillContentExamples(spec *OpenAPISpec, content map[string]MediaType) {
+	for mediaType, media := range content {
+		if media.Schema == nil || media.Example != nil {
+			continue
+		}
+
+		example := exampleFor(spec.Components.Schemas, media.Schema, make(map[string]bool))
+		if object, ok := example.(*orderedExample); ok && len(object.names) == 0 {
+			continue // Un objeto vacío no aporta nada
+		}
+
+		media.Example = example
+		content[mediaType] = media
+	}
+}
+
+// exampleFor compone el ejemplo de un schema resolviendo referencias; las referencias
+// que ya están en la pila (tipos recursivos) se omiten para que el ejemplo sea finito
+func exampleFor(components map[string]Schema, schema *Schema, visiting map[string]bool) interface{} {
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, componentsPrefix)
+		component, exists := components[name]
+		if !exists || visiting[name] {
+			return nil
+		}
+
+		visiting[name] = true
+		defer delete(visiting, name)
+		return exampleFor(components, &component, visiting)
+	}
+
+	switch {
+	case len(schema.AllOf) > 0:
+		return exampleFor(components, &schema.AllOf[0], visiting)
+	case len(schema.OneOf) > 0:
+		return exampleFor(components, &schema.OneOf[0], visiting)
+	}
+
+	switch schema.Type {
+	case "object":
+		object := &orderedExample{values: make(map[string]interface{})}
+		for _, name := range schema.PropertyNames() {
+			property := schema.Properties[name]
+			if value := exampleFor(components, &property, visiting); value != nil {
+				object.names = append(object.names, name)
+				object.values[name] = value
+			}
+		}
+		return object
+	case "array":
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+		if item := exampleFor(components, schema.Items, visiting); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case "string":
+		if schema.Format == "binary" {
+			return nil // El contenido de un archivo no tiene ejemplo textual
+		}
+		if placeholder, exists := placeholderExamples[schema.Format]; exists {
+			return placeholder
+		}
+		return "string"
+	case "integer", "number":
+		return numberExample(schema)
+	case "boolean":
+		return true
+	}
+
+	return nil
+}
+
+// numberExample usa 0 como ejemplo salvo que quede fuera de los límites del schema,
+// en cuyo caso toma el valor válido más cercano
+func numberExample(schema *Schema) interface{} {
+	exclusiveMinimum, exclusiveMaximum := schema.ExclusiveMinimum == true, schema.ExclusiveMaximum == true
+	if schema.Type == "number" && schema.Minimum != nil && schema.Maximum != nil && (exclusiveMinimum || exclusiveMaximum) {
+		return (*schema.Minimum + *schema.Maximum) / 2 // Intervalo abierto: el punto medio siempre es válido
+	}
+
+	value := 0.0
+	if minimum := schema.Minimum; minimum != nil && (value < *minimum || exclusiveMinimum && value == *minimum) {
+		switch {
+		case exclusiveMinimum:
+			value = math.Floor(*minimum) + 1
+		case schema.Type == "integer":
+			value = math.Ceil(*minimum)
+		default:
+			value = *minimum
+		}
+	}
+	if maximum := schema.Maximum; maximum != nil && (value > *maximum || exclusiveMaximum && value == *maximum) {
+		switch {
+		case exclusiveMaximum:
+			value = math.Ceil(*maximum) - 1
+		case schema.Type == "integer":
+			value = math.Floor(*maximum)
+		default:
+			value = *maximum
+		}
+	}
+
+	if schema.Type == "integer" {
+		return int64(value)
+	}
+	return value
+}
@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExamplesFromTagsAndConstants(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusDisabled Status = "disabled"
+)
+
+type Node struct {
+	Name     string ` + "`json:\"name\" example:\"root\"`" + `
+	Children []Node ` + "`json:\"children\"`" + `
+}
+
+type CreateUserRequest struct {
+	Name    string   ` + "`json:\"name\" example:\"John Doe\"`" + `
+	Age     int      ` + "`json:\"age\" example:\"30\"`" + `
+	Score   float64  ` + "`json:\"score\" example:\"9.5\"`" + `
+	Admin   bool     ` + "`json:\"admin\" example:\"true\"`" + `
+	Tags    []string ` + "`json:\"tags\" example:\"a,b\"`" + `
+	Status  Status   ` + "`json:\"status\"`" + `
+	SortDir string   ` + "`json:\"sort_dir\" binding:\"oneof=asc desc\"`" + `
+	Limit   int      ` + "`json:\"limit\" form:\"limit,default=10\"`" + `
+	Tree    Node     ` + "`json:\"tree\"`" + `
+}
+
+func CreateUser(c *gin.Context) {
+	var req CreateUserRequest
+	c.ShouldBindJSON(&req)
+	c.JSON(201, req)
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/users", CreateUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+	properties := spec.Components.Schemas["CreateUserRequest"].Properties
+
+	expected := map[string]interface{}{
+		"name":     "John Doe",
+		"age":      int64(30),
+		"score":    9.5,
+		"admin":    true,
+		"tags":     []interface{}{"a", "b"},
+		"status":   "active",
+		"sort_dir": "asc",
+		"limit":    int64(10),
+	}
+	for name, example := range expected {
+		if !reflect.DeepEqual(properties[name].Example, example) {
+			t.Errorf("Expected example %v for %s, got %#v", example, name, properties[name].Example)
+		}
+	}
+
+	if !reflect.DeepEqual(properties["status"].Enum, []interface{}{"active", "disabled"}) {
+		t.Errorf("Expected enum from constants, got %v", properties["status"].Enum)
+	}
+	if properties["limit"].Default != int64(10) {
+		t.Errorf("Expected default from form tag, got %v", properties["limit"].Default)
+	}
+
+	body := spec.Paths["/users"].Post.RequestBody.Content["application/json"].Example
+	object, ok := body.(*orderedExample)
+	if !ok || object.values["name"] != "John Doe" {
+		t.Fatalf("Expected request example object, got %#v", body)
+	}
+
+	// Las propiedades del ejemplo siguen el orden de declaración del struct
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("Failed to marshal example: %v", err)
+	}
+	if !strings.HasPrefix(string(data), `{"name":"John Doe","age":30,"score":9.5,"admin":true,`) {
+		t.Errorf("Expected example in declaration order, got %s", data)
+	}
+
+	// Node es recursivo: el ejemplo se corta en la referencia repetida
+	tree, ok := object.values["tree"].(*orderedExample)
+	if !ok || tree.values["name"] != "root" || !reflect.DeepEqual(tree.values["children"], []interface{}{}) {
+		t.Errorf("Expected finite example for recursive type, got %#v", object.values["tree"])
+	}
+
+	if spec.Paths["/users"].Post.Responses["201"].Content["application/json"].Example == nil {
+		t.Errorf("Expected response example")
+	}
+}
+
+func TestNumberExamplesWithinBounds(t *testing.T) {
+	floatPtr := func(value float64) *float64 { return &value }
+
+	tests := []struct {
+		schema   Schema
+		expected interface{}
+	}{
+		{Schema{Type: "integer"}, int64(0)},
+		{Schema{Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(100)}, int64(1)},
+		{Schema{Type: "integer", Minimum: floatPtr(0), ExclusiveMinimum: true}, int64(1)},
+		{Schema{Type: "integer", Minimum: floatPtr(-10), Maximum: floatPtr(-5)}, int64(-5)},
+		{Schema{Type: "integer", Maximum: floatPtr(0), ExclusiveMaximum: true}, int64(-1)},
+		{Schema{Type: "integer", Minimum: floatPtr(2.5)}, int64(3)},
+		{Schema{Type: "number", Minimum: floatPtr(0.5)}, 0.5},
+		{Schema{Type: "number", Minimum: floatPtr(0), ExclusiveMinimum: true, Maximum: floatPtr(1), ExclusiveMaximum: true}, 0.5},
+	}
+
+	for _, test := range tests {
+		example := exampleFor(nil, &test.schema, make(map[string]bool))
+		if !reflect.DeepEqual(example, test.expected) {
+			t.Errorf("Expected example %#v for %+v, got %#v", test.expected, test.schema, example)
+		}
+	}
+}
+
+func TestTagsOnNamedTypeFields(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+type Tree []Tree
+
+type UpdateUserRequest struct {
+	Role    Role    ` + "`json:\"role\" example:\"editor\" binding:\"oneof=admin editor\"`" + `
+	Address Address ` + "`json:\"address\" example:\"{\\\"city\\\":\\\"Lima\\\"}\"`" + `
+	Tree    Tree    ` + "`json:\"tree\" binding:\"max=3\"`" + `
+	Home    Address ` + "`json:\"home\"`" + `
+}
+
+func UpdateUser(c *gin.Context) {
+	var req UpdateUserRequest
+	c.ShouldBindJSON(&req)
+	c.JSON(200, req)
+}
+
+func main() {
+	r := gin.Default()
+	r.PUT("/users/:id", UpdateUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+	properties := spec.Components.Schemas["UpdateUserRequest"].Properties
+
+	role := properties["role"]
+	if !reflect.DeepEqual(role.Enum, []interface{}{"admin", "editor"}) || role.Example != "editor" {
+		t.Errorf("Expected oneof enum and example on enum field, got %+v", role)
+	}
+
+	// Los tags de un campo con $ref se aplican envolviendo la referencia en allOf
+	address := properties["address"]
+	if len(address.AllOf) != 1 || address.AllOf[0].Ref != "#/components/schemas/Address" {
+		t.Fatalf("Expected allOf wrapper around Address, got %+v", address)
+	}
+	if !reflect.DeepEqual(address.Example, map[string]interface{}{"city": "Lima"}) {
+		t.Errorf("Expected example object on Address field, got %#v", address.Example)
+	}
+
+	tree := properties["tree"]
+	if len(tree.AllOf) != 1 || tree.MaxItems == nil || *tree.MaxItems != 3 {
+		t.Errorf("Expected maxItems from the component type on Tree field, got %+v", tree)
+	}
+
+	if home := properties["home"]; home.Ref != "#/components/schemas/Address" || home.AllOf != nil {
+		t.Errorf("Expected plain $ref without tags, got %+v", home)
+	}
+}
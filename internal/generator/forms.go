@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"go/types"
+	"slices"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal/handler"
+)
+
+// bodyContentTypes devuelve los media types de un parámetro body (JSON si no declara)
+func bodyContentTypes(param handler.ParamInfo) []string {
+	if len(param.ContentTypes) == 0 {
+		return []string{"application/json"}
+	}
+	return param.ContentTypes
+}
+
+// isFormContentType indica si el media type se documenta con las propiedades del formulario
+func isFormContentType(contentType string) bool {
+	return contentType == handler.MultipartFormData || contentType == handler.FormURLEncoded
+}
+
+// formSchema construye el objeto de un cuerpo de formulario con los campos del struct
+// enlazado (tags form) y los leídos directamente con c.PostForm o c.FormFile
+func (g *OpenAPIGenerator) formSchema(boundType types.Type, fields []handler.ParamInfo) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]Schema),
+	}
+
+	addProperty := func(name string, property *Schema, required bool) {
+		if _, exists := schema.Properties[name]; exists {
+			return
+		}
+		schema.Properties[name] = *property
+		schema.propertyOrder = append(schema.propertyOrder, name)
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	if boundType != nil {
+		for _, field := range g.schemas.BoundFields(boundType, "form") {
+			addProperty(field.Name, field.Schema, field.Required)
+		}
+	}
+	for _, field := range fields {
+		addProperty(g.getParameterName(field), g.formFieldSchema(field), field.Required)
+	}
+
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema
+}
+
+// formFieldSchema documenta los archivos como binarios, uno o una lista
+func (g *OpenAPIGenerator) formFieldSchema(field handler.ParamInfo) *Schema {
+	switch field.Type {
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}
+	case "[]file":
+		return &Schema{Type: "array", Items: &Schema{Type: "string", Format: "binary"}}
+	default:
+		return g.paramToSchema(field)
+	}
+}
+
+// hasBinaryProperty indica si el formulario incluye archivos y requiere multipart
+func hasBinaryProperty(schema *Schema) bool {
+	for _, property := range schema.Properties {
+		if property.Format == "binary" || (property.Items != nil && property.Items.Format == "binary") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendBoundParameters agrega un parámetro por cada campo del struct enlazado; los
+// parámetros de path son siempre obligatorios y los nombres repetidos se ignoran
+func (g *OpenAPIGenerator) appendBoundParameters(parameters []Parameter, param handler.ParamInfo) []Parameter {
+	for _, field := range g.schemas.BoundFields(param.GoType, param.BindingTag) {
+		exists := slices.ContainsFunc(parameters, func(existing Parameter) bool {
+			return existing.Name == field.Name && existing.In == param.Location
+		})
+		if exists {
+			continue
+		}
+
+		// La descripción del campo pertenece al parámetro, no a su schema
+		description := field.Schema.Description
+		field.Schema.Description = ""
+
+		parameters = append(parameters, Parameter{
+			Name:        field.Name,
+			In:          param.Location,
+			Description: description,
+			Required:    field.Required || param.Location == "path",
+			Schema:      field.Schema,
+		})
+	}
+	return parameters
+}
+
+// formBindingsAsQuery refleja que en un GET gin enlaza los formularios desde la query
+// string (ShouldBind usa binding.Form y el cuerpo se ignora)
+func formBindingsAsQuery(handlerInfo *handler.HandlerInfo) *handler.HandlerInfo {
+	converted := *handlerInfo
+	converted.Params = make([]handler.ParamInfo, 0, len(handlerInfo.Params))
+	for _, param := range handlerInfo.Params {
+		if param.Location == "body" && param.BindingTag == "form" && slices.Contains(param.ContentTypes, handler.FormURLEncoded) {
+			param.Location = "query"
+			param.Required = false
+			param.ContentTypes = nil
+		}
+		converted.Params = append(converted.Params, param)
+	}
+	return &converted
+}
@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestMultipartUploads(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"mime/multipart"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileForm struct {
+	Name   string                  ` + "`form:\"name\" binding:\"required\"`" + `
+	Photo  *multipart.FileHeader   ` + "`form:\"photo\" binding:\"required\"`" + `
+	Extras []*multipart.FileHeader ` + "`form:\"extras\"`" + `
+}
+
+func UploadAvatar(c *gin.Context) {
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		c.AbortWithStatus(400)
+		return
+	}
+	caption := c.PostForm("caption")
+	_, _ = file, caption
+}
+
+func UploadDocuments(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return
+	}
+	files := form.File["documents"]
+	titles := form.Value["title"]
+	_, _ = files, titles
+}
+
+func CreateProfile(c *gin.Context) {
+	var req ProfileForm
+	if err := c.ShouldBind(&req); err != nil {
+		c.AbortWithStatus(400)
+		return
+	}
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/avatar", UploadAvatar)
+	r.POST("/documents", UploadDocuments)
+	r.POST("/profiles", CreateProfile)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	multipartSchema := func(route string) *Schema {
+		t.Helper()
+		body := findOperation(spec, route).RequestBody
+		if body == nil {
+			t.Fatalf("Expected request body on %s", route)
+		}
+		media, exists := body.Content["multipart/form-data"]
+		if !exists || len(body.Content) != 1 {
+			t.Fatalf("Expected only multipart/form-data on %s, got %v", route, body.Content)
+		}
+		return media.Schema
+	}
+
+	avatar := multipartSchema("POST /avatar")
+	if file := avatar.Properties["avatar"]; file.Type != "string" || file.Format != "binary" {
+		t.Errorf("Expected avatar as binary string, got %+v", file)
+	}
+	if caption := avatar.Properties["caption"]; caption.Type != "string" || caption.Format != "" {
+		t.Errorf("Expected caption as plain string, got %+v", caption)
+	}
+	if len(avatar.Required) != 1 || avatar.Required[0] != "avatar" {
+		t.Errorf("Expected avatar to be required, got %v", avatar.Required)
+	}
+
+	documents := multipartSchema("POST /documents")
+	if files := documents.Properties["documents"]; files.Type != "array" || files.Items == nil || files.Items.Format != "binary" {
+		t.Errorf("Expected documents as array of binaries, got %+v", files)
+	}
+	if titles := documents.Properties["title"]; titles.Type != "array" || titles.Items == nil || titles.Items.Type != "string" {
+		t.Errorf("Expected title as array of strings, got %+v", titles)
+	}
+
+	// Los campos del struct se nombran con el tag form, no con json
+	profile := multipartSchema("POST /profiles")
+	if names := profile.PropertyNames(); len(names) != 3 || names[0] != "name" || names[1] != "photo" || names[2] != "extras" {
+		t.Errorf("Expected properties [name photo extras], got %v", names)
+	}
+	if photo := profile.Properties["photo"]; photo.Type != "string" || photo.Format != "binary" {
+		t.Errorf("Expected photo as binary string, got %+v", photo)
+	}
+	if extras := profile.Properties["extras"]; extras.Type != "array" || extras.Items == nil || extras.Items.Format != "binary" {
+		t.Errorf("Expected extras as array of binaries, got %+v", extras)
+	}
+	if len(profile.Required) != 2 {
+		t.Errorf("Expected name and photo to be required, got %v", profile.Required)
+	}
+}
+
+func TestFormAndQueryBindings(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ListQuery struct {
+	// Página solicitada
+	Page   int    ` + "`form:\"page,default=1\"`" + `
+	Status string ` + "`form:\"status\" binding:\"oneof=active inactive\"`" + `
+	Search string ` + "`form:\"q\" binding:\"required\"`" + `
+}
+
+type LoginForm struct {
+	Username string ` + "`form:\"username\" json:\"user\" binding:\"required\"`" + `
+	Password string ` + "`form:\"password\" json:\"password\" binding:\"required\"`" + `
+}
+
+type SubscribeForm struct {
+	Email string ` + "`form:\"email\"`" + `
+}
+
+func ListUsers(c *gin.Context) {
+	var query ListQuery
+	_ = c.ShouldBindQuery(&query)
+}
+
+func SearchUsers(c *gin.Context) {
+	var query ListQuery
+	_ = c.ShouldBind(&query)
+}
+
+func Login(c *gin.Context) {
+	var form LoginForm
+	_ = c.ShouldBind(&form)
+}
+
+func Subscribe(c *gin.Context) {
+	var form SubscribeForm
+	_ = c.ShouldBindWith(&form, binding.Form)
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users", ListUsers)
+	r.GET("/users/search", SearchUsers)
+	r.POST("/login", Login)
+	r.POST("/subscribe", Subscribe)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	// ShouldBindQuery y ShouldBind en un GET documentan los campos como query
+	for _, route := range []string{"GET /users", "GET /users/search"} {
+		operation := findOperation(spec, route)
+		if operation.RequestBody != nil {
+			t.Errorf("Expected no request body on %s, got %+v", route, operation.RequestBody)
+		}
+
+		params := make(map[string]Parameter)
+		for _, param := range operation.Parameters {
+			if param.In != "query" {
+				t.Errorf("Expected query parameter on %s, got %s in %s", route, param.Name, param.In)
+			}
+			params[param.Name] = param
+		}
+		if len(params) != 3 {
+			t.Fatalf("Expected 3 query parameters on %s, got %+v", route, operation.Parameters)
+		}
+
+		if page := params["page"]; page.Schema.Type != "integer" || page.Schema.Default != int64(1) || page.Description != "Página solicitada" {
+			t.Errorf("Expected page integer with default 1 and description, got %+v", page)
+		}
+		if status := params["status"]; len(status.Schema.Enum) != 2 || status.Required {
+			t.Errorf("Expected optional status enum, got %+v", status)
+		}
+		if search := params["q"]; !search.Required {
+			t.Errorf("Expected q to be required, got %+v", search)
+		}
+	}
+
+	// ShouldBind acepta JSON o formulario según el Content-Type
+	login := findOperation(spec, "POST /login").RequestBody
+	if login == nil || len(login.Content) != 2 {
+		t.Fatalf("Expected JSON and urlencoded bodies on /login, got %+v", login)
+	}
+	if ref := login.Content["application/json"].Schema.Ref; ref != "#/components/schemas/LoginForm" {
+		t.Errorf("Expected JSON body to reference LoginForm, got %q", ref)
+	}
+	form := login.Content["application/x-www-form-urlencoded"].Schema
+	if names := form.PropertyNames(); len(names) != 2 || names[0] != "username" || names[1] != "password" {
+		t.Errorf("Expected form properties [username password], got %v", names)
+	}
+	if len(form.Required) != 2 {
+		t.Errorf("Expected required form fields, got %v", form.Required)
+	}
+
+	subscribe := findOperation(spec, "POST /subscribe").RequestBody
+	if subscribe == nil || len(subscribe.Content) != 1 {
+		t.Fatalf("Expected only urlencoded body on /subscribe, got %+v", subscribe)
+	}
+	if _, exists := subscribe.Content["application/x-www-form-urlencoded"]; !exists {
+		t.Errorf("Expected urlencoded body on /subscribe, got %v", subscribe.Content)
+	}
+}
+
+func TestHeaderAndURIBindings(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type RequestHeaders struct {
+	RequestID string ` + "`header:\"X-Request-ID\" binding:\"required,uuid\"`" + `
+	Locale    string ` + "`header:\"Accept-Language\"`" + `
+}
+
+type UserURI struct {
+	ID   int    ` + "`uri:\"id\" binding:\"required,min=1\"`" + `
+	Slug string ` + "`uri:\"slug\" binding:\"max=64\"`" + `
+}
+
+func GetUser(c *gin.Context) {
+	var headers RequestHeaders
+	if err := c.ShouldBindHeader(&headers); err != nil {
+		return
+	}
+	var uri UserURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		return
+	}
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id/:slug", GetUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	params := make(map[string]Parameter)
+	for _, param := range findOperation(spec, "GET /users/{id}/{slug}").Parameters {
+		params[param.In+" "+param.Name] = param
+	}
+	if len(params) != 4 {
+		t.Fatalf("Expected 4 parameters, got %v", params)
+	}
+
+	requestID := params["header X-Request-ID"]
+	if !requestID.Required || requestID.Schema.Format != "uuid" {
+		t.Errorf("Expected required uuid X-Request-ID header, got %+v", requestID)
+	}
+	if locale := params["header Accept-Language"]; locale.Required || locale.Schema.Type != "string" {
+		t.Errorf("Expected optional Accept-Language header, got %+v", locale)
+	}
+
+	id := params["path id"]
+	if !id.Required || id.Schema.Type != "integer" || id.Schema.Minimum == nil || *id.Schema.Minimum != 1 {
+		t.Errorf("Expected required integer id with minimum 1, got %+v", id.Schema)
+	}
+
+	// Los parámetros de path son obligatorios aunque el tag no lo diga
+	slug := params["path slug"]
+	if !slug.Required || slug.Schema.MaxLength == nil || *slug.Schema.MaxLength != 64 {
+		t.Errorf("Expected required slug with maxLength 64, got %+v", slug)
+	}
+}
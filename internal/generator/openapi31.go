@@ -0,0 +1,51 @@
+package generator
+
+// convertToOpenAPI31 adapta la especificación generada a OpenAPI 3.1 y a los
+// modismos de JSON Schema 2020-12
+func convertToOpenAPI31(spec *OpenAPISpec) {
+	spec.OpenAPI = OpenAPI31
+	walkSpecSchemas(spec, convertSchemaTo31)
+}
+
+// convertSchemaTo31 reemplaza las construcciones propias de OpenAPI 3.0
+func convertSchemaTo31(schema *Schema) {
+	// En 3.1 $ref admite hermanos: el envoltorio allOf ya no es necesario
+	if len(schema.AllOf) == 1 && schema.AllOf[0].Ref != "" && schema.Ref == "" {
+		schema.Ref = schema.AllOf[0].Ref
+		schema.AllOf = nil
+	}
+
+	// Los campos que admiten null → type: [T, "null"]
+	if schema.nullable {
+		schema.nullable = false
+		switch {
+		case schema.Type != "":
+			schema.typeWithNull = true
+		case schema.Ref != "":
+			schema.OneOf = []Schema{{Ref: schema.Ref}, {Type: "null"}}
+			schema.Ref = ""
+		}
+	}
+
+	// example → examples
+	if schema.Example != nil {
+		schema.Examples = []interface{}{schema.Example}
+		schema.Example = nil
+	}
+
+	// exclusiveMinimum: true + minimum → exclusiveMinimum: <límite>
+	if schema.ExclusiveMinimum == true && schema.Minimum != nil {
+		schema.ExclusiveMinimum = *schema.Minimum
+		schema.Minimum = nil
+	}
+	if schema.ExclusiveMaximum == true && schema.Maximum != nil {
+		schema.ExclusiveMaximum = *schema.Maximum
+		schema.Maximum = nil
+	}
+
+	// Un enum de un solo valor es una constante
+	if len(schema.Enum) == 1 {
+		schema.Const = schema.Enum[0]
+		schema.Enum = nil
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestOpenAPI31Output(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Kind string
+
+const KindUser Kind = "user"
+
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+type User struct {
+	Name     string   ` + "`json:\"name\" example:\"John\"`" + `
+	Nickname *string  ` + "`json:\"nickname\"`" + `
+	Address  *Address ` + "`json:\"address\"`" + `
+	Kind     Kind     ` + "`json:\"kind\"`" + `
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, User{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", GetUser)
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	openapiGenerator := NewOpenAPIGenerator(coordinator)
+
+	legacy, err := openapiGenerator.GenerateJSON(apiDesc, "Test API", "1.0.0")
+	if err != nil {
+		t.Fatalf("GenerateJSON failed: %v", err)
+	}
+	if !strings.Contains(string(legacy), `"openapi": "3.0.3"`) {
+		t.Errorf("Expected 3.0.3 output by default")
+	}
+	// En 3.0 nullable sin type no tiene significado: los punteros no se marcan
+	if strings.Contains(string(legacy), "nullable") || strings.Contains(string(legacy), "allOf") {
+		t.Errorf("Expected 3.0.3 output without nullable wrappers, got %s", legacy)
+	}
+
+	openapiGenerator.Config.OpenAPIVersion = OpenAPI31
+	data, err := openapiGenerator.GenerateJSON(apiDesc, "Test API", "1.0.0")
+	if err != nil {
+		t.Fatalf("GenerateJSON failed: %v", err)
+	}
+
+	var spec struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+
+	if spec.OpenAPI != "3.1.0" {
+		t.Errorf("Expected openapi 3.1.0, got %s", spec.OpenAPI)
+	}
+	if strings.Contains(string(data), "nullable") {
+		t.Errorf("3.1 output must not use nullable")
+	}
+
+	properties := spec.Components.Schemas["User"]["properties"].(map[string]interface{})
+
+	nickname := properties["nickname"].(map[string]interface{})
+	if types, ok := nickname["type"].([]interface{}); !ok || len(types) != 2 || types[1] != "null" {
+		t.Errorf("Expected type array with null for nickname, got %v", nickname["type"])
+	}
+
+	address := properties["address"].(map[string]interface{})
+	oneOf, ok := address["oneOf"].([]interface{})
+	if !ok || len(oneOf) != 2 || oneOf[0].(map[string]interface{})["$ref"] != "#/components/schemas/Address" {
+		t.Errorf("Expected nullable reference as oneOf with null, got %v", address)
+	}
+
+	name := properties["name"].(map[string]interface{})
+	if examples, ok := name["examples"].([]interface{}); !ok || examples[0] != "John" || name["example"] != nil {
+		t.Errorf("Expected examples array instead of example, got %v", name)
+	}
+
+	if kind := properties["kind"].(map[string]interface{}); kind["const"] != "user" {
+		t.Errorf("Expected single-value enum as const, got %v", kind)
+	}
+}
@@ -3,29 +3,40 @@ package generator
 import (
 	"encoding/json"
 	"fmt"
+	"go/types"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Larry-Baltodano/go-auto-swagger/internal"
 	"github.com/Larry-Baltodano/go-auto-swagger/internal/handler"
 )
 
 type OpenAPIGenerator struct {
-	coordinator *internal.EnhancedCoordinator
+	Config          Config
+	coordinator     *internal.EnhancedCoordinator
+	schemas         *SchemaBuilder
+	securitySchemes map[string]SecurityScheme
+	envelope        types.Type // Tipo de error del proyecto (nil si no se detectó)
 }
 
 // NewOpenAPIGenerator crea un nuevo generador
 func NewOpenAPIGenerator(coordinator *internal.EnhancedCoordinator) *OpenAPIGenerator {
 	return &OpenAPIGenerator{
+		Config:      DefaultConfig(),
 		coordinator: coordinator,
 	}
 }
 
 type OpenAPISpec struct {
-	OpenAPI    string                 `json:"openapi"`
-	Info       Info                   `json:"info"`
-	Paths      map[string]PathItem    `json:"paths"`
-	Components *Components            `json:"components,omitempty"`
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Webhooks   map[string]PathItem `json:"webhooks,omitempty"` // Solo OpenAPI 3.1
+	Components *Components         `json:"components,omitempty"`
 }
 
 type Info struct {
@@ -34,21 +45,27 @@ type Info struct {
 	Version     string `json:"version"`
 }
 
+// PathItem declara las operaciones en el orden de la especificación OpenAPI
 type PathItem struct {
-	Get     *Operation `json:"get,omitempty"`
-	Post    *Operation `json:"post,omitempty"`
-	Put     *Operation `json:"put,omitempty"`
-	Delete  *Operation `json:"delete,omitempty"`
-	Patch   *Operation `json:"patch,omitempty"`
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
 }
 
 type Operation struct {
-	Summary     string               `json:"summary,omitempty"`
-	Description string               `json:"description,omitempty"`
-	Tags        []string             `json:"tags,omitempty"`
-	Parameters  []Parameter          `json:"parameters,omitempty"`
-	RequestBody *RequestBody         `json:"requestBody,omitempty"`
-	Responses   map[string]Response  `json:"responses"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	Roles       []string              `json:"x-roles,omitempty"`
+	WebSocket   *WebSocketExtension   `json:"x-websocket,omitempty"`
 }
 
 type Parameter struct {
@@ -66,30 +83,96 @@ type RequestBody struct {
 }
 
 type Response struct {
-	Description string               `json:"description"`
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"` // Obligatoria salvo en referencias
+	Headers     map[string]Header    `json:"headers,omitempty"`
 	Content     map[string]MediaType `json:"content,omitempty"`
 }
 
+// Header describe un header de respuesta
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
 type MediaType struct {
-	Schema *Schema `json:"schema,omitempty"`
+	Schema  *Schema     `json:"schema,omitempty"`
+	Example interface{} `json:"example,omitempty"`
 }
 
 type Schema struct {
-	Type       string            `json:"type,omitempty"`
-	Format     string            `json:"format,omitempty"`
-	Properties map[string]Schema `json:"properties,omitempty"`
-	Items      *Schema           `json:"items,omitempty"`
-	Required   []string          `json:"required,omitempty"`
-	Example    interface{}       `json:"example,omitempty"`
+	Ref                  string            `json:"$ref,omitempty"`
+	Type                 string            `json:"type,omitempty"`
+	Format               string            `json:"format,omitempty"`
+	Description          string            `json:"description,omitempty"`
+	Deprecated           bool              `json:"deprecated,omitempty"`
+	Properties           map[string]Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema           `json:"additionalProperties,omitempty"`
+	Items                *Schema           `json:"items,omitempty"`
+	Required             []string          `json:"required,omitempty"`
+	AllOf                []Schema          `json:"allOf,omitempty"`
+	OneOf                []Schema          `json:"oneOf,omitempty"`
+	Discriminator        *Discriminator    `json:"discriminator,omitempty"`
+	Enum                 []interface{}     `json:"enum,omitempty"`
+	Minimum              *float64          `json:"minimum,omitempty"`
+	Maximum              *float64          `json:"maximum,omitempty"`
+	ExclusiveMinimum     interface{}       `json:"exclusiveMinimum,omitempty"` // bool en 3.0, número en 3.1
+	ExclusiveMaximum     interface{}       `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int              `json:"minLength,omitempty"`
+	MaxLength            *int              `json:"maxLength,omitempty"`
+	MinItems             *int              `json:"minItems,omitempty"`
+	MaxItems             *int              `json:"maxItems,omitempty"`
+	Pattern              string            `json:"pattern,omitempty"`
+	Const                interface{}       `json:"const,omitempty"` // OpenAPI 3.1
+	Default              interface{}       `json:"default,omitempty"`
+	Example              interface{}       `json:"example,omitempty"`
+	Examples             []interface{}     `json:"examples,omitempty"` // OpenAPI 3.1
+
+	nullable      bool     // Campo puntero sin omitempty: admite null (solo se emite en 3.1)
+	typeWithNull  bool     // OpenAPI 3.1: "type" se emite como [Type, "null"]
+	propertyOrder []string // Orden de declaración de los campos del struct
+}
+
+// MarshalJSON emite las propiedades en el orden de declaración del struct y "type"
+// como array cuando el schema admite null en OpenAPI 3.1
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type plainSchema Schema
+	// Los campos sobrescritos van antes del embebido para conservar el orden de las claves
+	out := struct {
+		Ref         string             `json:"$ref,omitempty"`
+		Type        interface{}        `json:"type,omitempty"`
+		Format      string             `json:"format,omitempty"`
+		Description string             `json:"description,omitempty"`
+		Properties  *orderedProperties `json:"properties,omitempty"`
+		plainSchema
+	}{Ref: s.Ref, Format: s.Format, Description: s.Description, plainSchema: plainSchema(s)}
+
+	if s.Type != "" {
+		out.Type = s.Type
+	}
+	if s.typeWithNull {
+		out.Type = []string{s.Type, "null"}
+	}
+	if len(s.Properties) > 0 {
+		out.Properties = &orderedProperties{names: s.PropertyNames(), schemas: s.Properties}
+	}
+	return json.Marshal(out)
+}
+
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
 }
 
 type Components struct {
-	Schemas map[string]Schema `json:"schemas,omitempty"`
+	Schemas         map[string]Schema         `json:"schemas,omitempty"`
+	Responses       map[string]Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
 }
 
 func (g *OpenAPIGenerator) Generate(apiDesc *internal.APIDescription, title, version string) *OpenAPISpec {
 	spec := &OpenAPISpec{
-		OpenAPI: "3.0.3",
+		OpenAPI: OpenAPI30,
 		Info: Info{
 			Title:   title,
 			Version: version,
@@ -97,18 +180,58 @@ func (g *OpenAPIGenerator) Generate(apiDesc *internal.APIDescription, title, ver
 		Paths:      make(map[string]PathItem),
 		Components: &Components{},
 	}
+	g.schemas = NewSchemaBuilder(g.Config, g.coordinator.HandlerAnalyzer)
+	g.securitySchemes = make(map[string]SecurityScheme)
+	g.envelope = g.errorEnvelope(apiDesc)
 
 	// Generar paths y operaciones
 	g.generatePaths(spec, apiDesc)
+	g.generateDefaultResponses(spec)
+	if len(g.securitySchemes) > 0 {
+		spec.Components.SecuritySchemes = g.securitySchemes
+	}
+
+	// Asignar nombres finales a los componentes y actualizar las referencias
+	refs := g.schemas.ResolveNames()
+	spec.Components.Schemas = g.schemas.Schemas()
+	walkSpecSchemas(spec, func(schema *Schema) {
+		if ref, exists := refs[schema.Ref]; exists {
+			schema.Ref = ref
+		}
+	})
 
-	// Generar schemas de componentes si es necesario
-	g.generateSchemas(spec, apiDesc)
+	// Ejemplos completos de request y response para "Try it out"
+	g.generateExamples(spec)
+
+	if g.Config.OpenAPIVersion == OpenAPI31 {
+		convertToOpenAPI31(spec)
+	}
 
 	return spec
 }
 
+// Diagnostics devuelve los avisos de la última generación
+func (g *OpenAPIGenerator) Diagnostics() []string {
+	if g.schemas == nil {
+		return nil
+	}
+	return g.schemas.Diagnostics()
+}
+
+// Renames devuelve los componentes de la última generación que se calificaron
+// por colisión de nombres (tipo calificado → nombre del componente)
+func (g *OpenAPIGenerator) Renames() map[string]string {
+	if g.schemas == nil {
+		return nil
+	}
+	return g.schemas.Renames()
+}
+
 func (g *OpenAPIGenerator) generatePaths(spec *OpenAPISpec, apiDesc *internal.APIDescription) {
-	for _, route := range apiDesc.Routes {
+	var operations []operationRef
+	registered := make(map[string]int)
+
+	for _, route := range sortedRoutes(apiDesc.Routes) {
 		// Crear o obtener el path item
 		pathItem, exists := spec.Paths[route.Path]
 		if !exists {
@@ -130,10 +253,23 @@ func (g *OpenAPIGenerator) generatePaths(spec *OpenAPISpec, apiDesc *internal.AP
 			pathItem.Delete = operation
 		case "PATCH":
 			pathItem.Patch = operation
+		default:
+			continue
 		}
 
 		spec.Paths[route.Path] = pathItem
+
+		// Una ruta registrada dos veces reemplaza a la anterior
+		key := route.Method + " " + route.Path
+		if index, exists := registered[key]; exists {
+			operations[index] = operationRef{operation, route}
+		} else {
+			registered[key] = len(operations)
+			operations = append(operations, operationRef{operation, route})
+		}
 	}
+
+	g.assignOperationIDs(operations)
 }
 
 func (g *OpenAPIGenerator) routeToOperation(route internal.RouteDescription) *Operation {
@@ -142,18 +278,32 @@ func (g *OpenAPIGenerator) routeToOperation(route internal.RouteDescription) *Op
 		Description: g.generateDescription(route),
 		Tags:        g.generateTags(route),
 		Responses:   g.generateResponses(route),
+		Security:    g.generateSecurity(route),
+		Roles:       middlewareArguments(route, g.Config.RoleMiddleware),
+		WebSocket:   g.webSocketExtension(route.HandlerInfo),
 	}
 
 	// Generar parámetros y request body
 	if route.HandlerInfo != nil {
-		operation.Parameters = g.generateParameters(route.HandlerInfo)
-		operation.RequestBody = g.generateRequestBody(route.HandlerInfo)
+		handlerInfo := route.HandlerInfo
+		if route.Method == "GET" {
+			handlerInfo = formBindingsAsQuery(handlerInfo)
+		}
+
+		_, operation.Deprecated = parseDocComment(handlerInfo.Doc)
+		operation.Parameters = g.generateParameters(handlerInfo)
+		operation.RequestBody = g.generateRequestBody(handlerInfo)
 	}
 
 	return operation
 }
 
 func (g *OpenAPIGenerator) generateSummary(route internal.RouteDescription) string {
+	if route.HandlerInfo != nil && route.HandlerInfo.Doc != "" {
+		summary, _ := splitHandlerDoc(route.HandlerInfo.Doc, route.HandlerInfo.Name)
+		return summary
+	}
+
 	handlerName := route.Handler
 	parts := strings.Split(handlerName, ".")
 	if len(parts) > 0 {
@@ -178,9 +328,44 @@ func (g *OpenAPIGenerator) generateSummary(route internal.RouteDescription) stri
 }
 
 func (g *OpenAPIGenerator) generateDescription(route internal.RouteDescription) string {
+	if route.HandlerInfo != nil && route.HandlerInfo.Doc != "" {
+		_, description := splitHandlerDoc(route.HandlerInfo.Doc, route.HandlerInfo.Name)
+		return description
+	}
+
 	return fmt.Sprintf("Automatically generated endpoint for %s %s", route.Method, route.Path)
 }
 
+// splitHandlerDoc separa el comentario del handler en resumen (primera oración)
+// y descripción (el resto), quitando el nombre de la función al inicio:
+// "GetUserByID obtiene usuario por ID" → "Obtiene usuario por ID"
+func splitHandlerDoc(doc, functionName string) (string, string) {
+	doc = strings.TrimSpace(doc)
+	if rest, ok := strings.CutPrefix(doc, functionName+" "); ok {
+		doc = strings.TrimSpace(rest)
+	}
+
+	// La primera oración termina en un punto seguido de espacio o al final del primer párrafo
+	end := len(doc)
+	if paragraph := strings.Index(doc, "\n\n"); paragraph != -1 {
+		end = paragraph
+	}
+	for i := 0; i < end; i++ {
+		if doc[i] == '.' && (i+1 == len(doc) || doc[i+1] == ' ' || doc[i+1] == '\n') {
+			end = i + 1
+			break
+		}
+	}
+
+	summary := strings.Join(strings.Fields(doc[:end]), " ")
+	summary = strings.TrimSuffix(summary, ".")
+	if first, size := utf8.DecodeRuneInString(summary); size > 0 {
+		summary = string(unicode.ToUpper(first)) + summary[size:]
+	}
+
+	return summary, strings.TrimSpace(doc[end:])
+}
+
 func (g *OpenAPIGenerator) generateTags(route internal.RouteDescription) []string {
 	// Extraer el primer segmento del path como tag
 	path := strings.Trim(route.Path, "/")
@@ -195,8 +380,14 @@ func (g *OpenAPIGenerator) generateParameters(handlerInfo *handler.HandlerInfo)
 	var parameters []Parameter
 
 	for _, param := range handlerInfo.Params {
+		// Structs enlazados con ShouldBindQuery y similares: cada campo es un parámetro
+		if param.BindingTag != "" && param.GoType != nil && param.Location != "body" {
+			parameters = g.appendBoundParameters(parameters, param)
+			continue
+		}
+
 		// Solo generar parámetros para locations que van en parameters (no body)
-		if param.Location == "path" || param.Location == "query" || param.Location == "header" {
+		if param.Location == "path" || param.Location == "query" || param.Location == "header" || param.Location == "cookie" {
 			paramSchema := g.paramToSchema(param)
 
 			parameter := Parameter{
@@ -233,55 +424,169 @@ func (g *OpenAPIGenerator) generateParameters(handlerInfo *handler.HandlerInfo)
 
 func (g *OpenAPIGenerator) generateRequestBody(handlerInfo *handler.HandlerInfo) *RequestBody {
 	var bodyParams []handler.ParamInfo
+	var formFields []handler.ParamInfo
 
-	// Encontrar todos los parámetros que son body
+	// Encontrar los parámetros body y los campos de formulario leídos del contexto
 	for _, param := range handlerInfo.Params {
-		if param.Location == "body" {
+		switch param.Location {
+		case "body":
 			bodyParams = append(bodyParams, param)
+		case "form":
+			formFields = append(formFields, param)
+		}
+
+		// Campos de struct con tag form (determineFieldLocation)
+		for _, subParam := range param.SubParams {
+			if subParam.Location == "form" {
+				formFields = append(formFields, subParam)
+			}
 		}
 	}
 
-	if len(bodyParams) == 0 {
+	if len(bodyParams) == 0 && len(formFields) == 0 {
 		return nil
 	}
 
-	// Por simplicidad, tomamos el primer parámetro body
-	// En una implementación completa, podríamos combinar múltiples body params
-	bodyParam := bodyParams[0]
+	// Por simplicidad, cada media type se documenta con el primer parámetro que lo acepta
+	content := make(map[string]MediaType)
+	required := false
+	for _, param := range bodyParams {
+		required = required || param.Required
+		for _, contentType := range bodyContentTypes(param) {
+			if _, exists := content[contentType]; exists {
+				continue
+			}
+			if isFormContentType(contentType) {
+				content[contentType] = MediaType{Schema: g.formSchema(param.GoType, formFields)}
+			} else {
+				content[contentType] = MediaType{Schema: g.paramToSchema(param)}
+			}
+		}
+	}
+
+	// Campos sueltos (c.PostForm, c.FormFile) sin un struct de formulario enlazado
+	_, multipart := content[handler.MultipartFormData]
+	_, urlencoded := content[handler.FormURLEncoded]
+	if len(formFields) > 0 && !multipart && !urlencoded {
+		schema := g.formSchema(nil, formFields)
+		contentType := handler.FormURLEncoded
+		if hasBinaryProperty(schema) {
+			contentType = handler.MultipartFormData
+		}
+		content[contentType] = MediaType{Schema: schema}
+		required = required || len(schema.Required) > 0
+	}
 
-	// Crear schema para el body
-	schema := g.paramToSchema(bodyParam)
+	name := "form"
+	if len(bodyParams) > 0 {
+		name = bodyParams[0].Name
+	}
 
 	return &RequestBody{
-		Description: fmt.Sprintf("%s data", bodyParam.Name),
-		Required:    bodyParam.Required,
-		Content: map[string]MediaType{
-			"application/json": {
-				Schema: schema,
-			},
-		},
+		Description: fmt.Sprintf("%s data", name),
+		Required:    required,
+		Content:     content,
 	}
 }
 
 func (g *OpenAPIGenerator) generateResponses(route internal.RouteDescription) map[string]Response {
 	responses := make(map[string]Response)
 
-	// Respuesta exitosa basada en el método HTTP
-	successCode := "200"
+	// Respuestas escritas por el handler (c.JSON, c.AbortWithStatusJSON, c.AbortWithStatus...)
+	if route.HandlerInfo != nil {
+		for _, response := range route.HandlerInfo.Responses {
+			if response.StatusCode == 0 {
+				continue // Código no constante
+			}
+
+			code := strconv.Itoa(response.StatusCode)
+			written, exists := responses[code]
+			if !exists {
+				written.Description = http.StatusText(response.StatusCode)
+				if response.StatusCode >= 200 && response.StatusCode < 300 {
+					written.Description = "Success"
+				}
+				if response.Description != "" {
+					written.Description += ". " + response.Description
+				}
+			}
+
+			// El mismo código puede escribirse en varios formatos (c.JSON y c.XML, c.Negotiate)
+			if response.HasBody() {
+				contentType := response.ContentType
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				if _, exists := written.Content[contentType]; !exists {
+					if written.Content == nil {
+						written.Content = make(map[string]MediaType)
+					}
+					written.Content[contentType] = MediaType{Schema: g.responseSchema(response, contentType)}
+				}
+			}
+
+			for _, header := range response.Headers {
+				if _, exists := written.Headers[header.Name]; !exists {
+					if written.Headers == nil {
+						written.Headers = make(map[string]Header)
+					}
+					written.Headers[header.Name] = responseHeader(header)
+				}
+			}
+			responses[code] = written
+		}
+	}
+
+	if !hasSuccessResponse(responses) {
+		responses[g.defaultSuccessCode(route)] = g.defaultSuccessResponse(route)
+	}
+
+	// Los headers que fijan los middlewares de la ruta acompañan a todas sus respuestas
+	for code, response := range responses {
+		for _, middleware := range route.Middleware {
+			for _, header := range middleware.Headers {
+				if _, exists := response.Headers[header.Name]; !exists {
+					if response.Headers == nil {
+						response.Headers = make(map[string]Header)
+					}
+					response.Headers[header.Name] = responseHeader(header)
+				}
+			}
+		}
+		responses[code] = response
+	}
+
+	// Respuestas globales configuradas, declaradas una vez en components.responses
+	for _, statusCode := range g.Config.DefaultResponses {
+		code := strconv.Itoa(statusCode)
+		if _, exists := responses[code]; !exists {
+			responses[code] = Response{Ref: responsesPrefix + responseComponentName(statusCode)}
+		}
+	}
+
+	return responses
+}
+
+// defaultSuccessCode infiere el código de éxito a partir del método HTTP
+func (g *OpenAPIGenerator) defaultSuccessCode(route internal.RouteDescription) string {
 	switch route.Method {
 	case "POST":
-		successCode = "201"
+		return "201"
 	case "DELETE":
-		successCode = "204"
+		return "204"
+	default:
+		return "200"
 	}
+}
 
+// defaultSuccessResponse crea la respuesta exitosa cuando no se detectó ninguna escritura
+func (g *OpenAPIGenerator) defaultSuccessResponse(route internal.RouteDescription) Response {
 	// Crear schema de respuesta si tenemos información del handler
 	var responseSchema *Schema
 	if route.HandlerInfo != nil && route.HandlerInfo.ReturnType != "" {
 		responseSchema = g.returnTypeToSchema(route.HandlerInfo.ReturnType)
 	}
 
-	// Respuesta exitosa
 	successResponse := Response{
 		Description: "Success",
 	}
@@ -294,42 +599,15 @@ func (g *OpenAPIGenerator) generateResponses(route internal.RouteDescription) ma
 		}
 	}
 
-	responses[successCode] = successResponse
-
-	// Respuestas de error comunes
-	errorResponses := map[string]string{
-		"400": "Bad Request",
-		"401": "Unauthorized",
-		"403": "Forbidden",
-		"404": "Not Found",
-		"500": "Internal Server Error",
-	}
-
-	for code, description := range errorResponses {
-		responses[code] = Response{
-			Description: description,
-			Content: map[string]MediaType{
-				"application/json": {
-					Schema: &Schema{
-						Type: "object",
-						Properties: map[string]Schema{
-							"error": {
-								Type: "string",
-							},
-							"message": {
-								Type: "string",
-							},
-						},
-					},
-				},
-			},
-		}
-	}
-
-	return responses
+	return successResponse
 }
 
 func (g *OpenAPIGenerator) paramToSchema(param handler.ParamInfo) *Schema {
+	// Los tipos resueltos con go/types se construyen completos (structs como componentes)
+	if param.GoType != nil {
+		return g.schemas.SchemaFor(param.GoType)
+	}
+
 	openAPIType, format := g.coordinator.HandlerAnalyzer.GetOpenAPIType(param.Type)
 
 	schema := &Schema{
@@ -373,30 +651,6 @@ func (g *OpenAPIGenerator) returnTypeToSchema(returnType string) *Schema {
 	return schema
 }
 
-func (g *OpenAPIGenerator) generateSchemas(spec *OpenAPISpec, apiDesc *internal.APIDescription) {
-	// Por ahora, esta es una implementación básica
-	// En una implementación completa, analizaríamos todos los structs usados
-	if spec.Components.Schemas == nil {
-		spec.Components.Schemas = make(map[string]Schema)
-	}
-
-	// Agregar schema de error básico
-	spec.Components.Schemas["Error"] = Schema{
-		Type: "object",
-		Properties: map[string]Schema{
-			"error": {
-				Type: "string",
-			},
-			"message": {
-				Type: "string",
-			},
-			"code": {
-				Type: "integer",
-			},
-		},
-	}
-}
-
 func (g *OpenAPIGenerator) getParameterName(param handler.ParamInfo) string {
 	if param.JSONName != "" {
 		return param.JSONName
@@ -433,5 +687,11 @@ func (g *OpenAPIGenerator) SaveToFile(apiDesc *internal.APIDescription, filename
 		return err
 	}
 
+	// .yaml/.yml se escriben en YAML; el resto en JSON
+	data, err = EncodeSpec(data, FormatFromFilename(filename))
+	if err != nil {
+		return err
+	}
+
 	return os.WriteFile(filename, data, 0644)
 }
\ No newline at end of file
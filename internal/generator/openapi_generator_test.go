@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+// generateFromSource escribe el código en un directorio temporal y genera la especificación
+func generateFromSource(t *testing.T, source string) *OpenAPISpec {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	return NewOpenAPIGenerator(coordinator).Generate(apiDesc, "Test API", "1.0.0")
+}
+
+func TestSplitHandlerDoc(t *testing.T) {
+	tests := []struct {
+		doc         string
+		name        string
+		summary     string
+		description string
+	}{
+		{"GetUserByID obtiene usuario por ID\n", "GetUserByID", "Obtiene usuario por ID", ""},
+		{"ListUsers lista usuarios. Admite filtros\npor nombre.\n", "ListUsers", "Lista usuarios", "Admite filtros\npor nombre."},
+		{"Crea un usuario\nnuevo\n\nDevuelve 201 con el usuario.\n", "CreateUser", "Crea un usuario nuevo", "Devuelve 201 con el usuario."},
+		{"Versión 1.2 del listado.\n", "List", "Versión 1.2 del listado", ""},
+	}
+
+	for _, test := range tests {
+		summary, description := splitHandlerDoc(test.doc, test.name)
+		if summary != test.summary {
+			t.Errorf("splitHandlerDoc(%q) summary = %q, expected %q", test.doc, summary, test.summary)
+		}
+		if description != test.description {
+			t.Errorf("splitHandlerDoc(%q) description = %q, expected %q", test.doc, description, test.description)
+		}
+	}
+}
+
+func TestHandlerDocOnMethodHandlers(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type APIHandler struct{}
+
+// GetUserByID obtiene usuario por ID.
+//
+// Deprecated: usar /v2/users/:id.
+func (h *APIHandler) GetUserByID(c *gin.Context) {}
+
+func (h *APIHandler) ListUsers(c *gin.Context) {}
+
+func main() {
+	r := gin.Default()
+	api := &APIHandler{}
+	r.GET("/users/:id", api.GetUserByID)
+	r.GET("/users", api.ListUsers)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	operation := spec.Paths["/users/{id}"].Get
+	if operation.Summary != "Obtiene usuario por ID" {
+		t.Errorf("Expected summary from doc comment, got %q", operation.Summary)
+	}
+	if operation.Description != "Deprecated: usar /v2/users/:id." || !operation.Deprecated {
+		t.Errorf("Expected deprecated operation with remaining doc as description, got %+v", operation)
+	}
+
+	list := spec.Paths["/users"].Get
+	if list.Summary != "List Users" || list.Description != "Automatically generated endpoint for GET /users" {
+		t.Errorf("Expected heuristic summary without doc comment, got %q / %q", list.Summary, list.Description)
+	}
+}
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+// operationRef identifica una operación generada junto con la ruta que la originó
+type operationRef struct {
+	operation *Operation
+	route     internal.RouteDescription
+}
+
+// assignOperationIDs asigna un operationId único a cada operación de forma determinista:
+//  1. los overrides de la configuración ("GET /users/{id}" o "APIHandler.GetUserByID")
+//  2. el nombre del handler en lowerCamelCase (APIHandler.GetUserByID → getUserByID)
+//  3. si colisiona, el nombre calificado con el receptor o paquete (adminGetUser)
+//  4. si aún colisiona, un sufijo numérico en el orden canónico de las rutas (getUser2)
+func (g *OpenAPIGenerator) assignOperationIDs(operations []operationRef) {
+	used := make(map[string]bool)
+	var pending []operationRef
+
+	for _, ref := range operations {
+		if id, exists := g.operationIDOverride(ref.route); exists {
+			ref.operation.OperationID = id
+			used[id] = true
+			continue
+		}
+		pending = append(pending, ref)
+	}
+
+	// Contar los candidatos para calificar solo los nombres que se repiten
+	counts := make(map[string]int)
+	for _, ref := range pending {
+		counts[operationIDBase(ref.route)]++
+	}
+
+	for _, ref := range pending {
+		id := operationIDBase(ref.route)
+		if counts[id] > 1 || used[id] {
+			if qualifier := operationIDQualifier(ref.route); qualifier != "" {
+				id = lowerCamel(qualifier) + upperFirst(id)
+			}
+		}
+
+		candidate := id
+		for suffix := 2; used[candidate]; suffix++ {
+			candidate = fmt.Sprintf("%s%d", id, suffix)
+		}
+
+		ref.operation.OperationID = candidate
+		used[candidate] = true
+	}
+}
+
+// operationIDOverride busca un operationId configurado para la ruta o para su handler
+func (g *OpenAPIGenerator) operationIDOverride(route internal.RouteDescription) (string, bool) {
+	if id, exists := g.Config.OperationIDs[route.Method+" "+route.Path]; exists {
+		return id, true
+	}
+	if route.HandlerInfo != nil && route.HandlerInfo.Receiver != "" {
+		if id, exists := g.Config.OperationIDs[route.HandlerInfo.Receiver+"."+route.HandlerInfo.Name]; exists {
+			return id, true
+		}
+	}
+	id, exists := g.Config.OperationIDs[route.Handler]
+	return id, exists
+}
+
+// operationIDBase deriva el operationId del nombre del handler; los handlers anónimos
+// usan el método y el path (GET /users/{id} → getUsersById)
+func operationIDBase(route internal.RouteDescription) string {
+	parts := strings.Split(route.Handler, ".")
+	name := parts[len(parts)-1]
+	if name != "anonymous" && name != "unknown" && name != "" {
+		return lowerCamel(name)
+	}
+
+	var id strings.Builder
+	id.WriteString(strings.ToLower(route.Method))
+	for _, segment := range strings.Split(route.Path, "/") {
+		if param, ok := strings.CutPrefix(segment, "{"); ok {
+			id.WriteString("By")
+			segment = strings.TrimSuffix(param, "}")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			id.WriteString(upperFirst(word))
+		}
+	}
+	return id.String()
+}
+
+// operationIDQualifier devuelve el receptor del método o el paquete/variable del handler
+func operationIDQualifier(route internal.RouteDescription) string {
+	if route.HandlerInfo != nil && route.HandlerInfo.Receiver != "" {
+		return route.HandlerInfo.Receiver
+	}
+	if parts := strings.Split(route.Handler, "."); len(parts) > 1 {
+		return parts[len(parts)-2]
+	}
+	return ""
+}
+
+// lowerCamel pasa a minúsculas el prefijo en mayúsculas de un identificador Go,
+// respetando los acrónimos: GetUserByID → getUserByID, HTTPStatus → httpStatus, ID → id
+func lowerCamel(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+
+	// En "HTTPStatus" la última mayúscula inicia la siguiente palabra
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+func upperFirst(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
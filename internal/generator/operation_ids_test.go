@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestOperationIDs(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type APIHandler struct{}
+
+func (h *APIHandler) GetUserByID(c *gin.Context) {}
+
+type UserHandler struct{}
+
+func (h *UserHandler) List(c *gin.Context) {}
+
+type AdminHandler struct{}
+
+func (h *AdminHandler) List(c *gin.Context) {}
+
+func Health(c *gin.Context) {}
+
+func main() {
+	r := gin.Default()
+	api := &APIHandler{}
+	users := &UserHandler{}
+	admins := &AdminHandler{}
+
+	r.GET("/users/:id", api.GetUserByID)
+	r.GET("/users", users.List)
+	r.GET("/admins", admins.List)
+	r.GET("/health", Health)
+	r.GET("/healthz", Health)
+	r.GET("/ping", func(c *gin.Context) {})
+	r.DELETE("/users/:id", func(c *gin.Context) {})
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	openapiGenerator := NewOpenAPIGenerator(coordinator)
+	spec := openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+
+	expected := map[string]string{
+		"GET /users/{id}":    "getUserByID",
+		"GET /users":         "userHandlerList",
+		"GET /admins":        "adminHandlerList",
+		"GET /health":        "health",
+		"GET /healthz":       "health2",
+		"GET /ping":          "getPing",
+		"DELETE /users/{id}": "deleteUsersById",
+	}
+	for route, id := range expected {
+		if operation := findOperation(spec, route); operation == nil || operation.OperationID != id {
+			t.Errorf("Expected operationId %s for %s, got %+v", id, route, operation)
+		}
+	}
+
+	// Los overrides tienen prioridad y el nombre generado evita el valor reservado
+	openapiGenerator.Config.OperationIDs = map[string]string{
+		"GET /healthz":           "health",
+		"APIHandler.GetUserByID": "fetchUser",
+	}
+	spec = openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+
+	expected = map[string]string{
+		"GET /users/{id}": "fetchUser",
+		"GET /healthz":    "health",
+		"GET /health":     "health2",
+	}
+	for route, id := range expected {
+		if operation := findOperation(spec, route); operation == nil || operation.OperationID != id {
+			t.Errorf("Expected operationId %s for %s with overrides, got %+v", id, route, operation)
+		}
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	tests := map[string]string{
+		"GetUserByID":  "getUserByID",
+		"HTTPStatus":   "httpStatus",
+		"ID":           "id",
+		"HTTP2Handler": "http2Handler",
+		"list":         "list",
+	}
+	for name, expected := range tests {
+		if result := lowerCamel(name); result != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, name, result)
+		}
+	}
+}
+
+func findOperation(spec *OpenAPISpec, route string) *Operation {
+	for path, pathItem := range spec.Paths {
+		operations := map[string]*Operation{
+			"GET " + path:    pathItem.Get,
+			"PUT " + path:    pathItem.Put,
+			"POST " + path:   pathItem.Post,
+			"DELETE " + path: pathItem.Delete,
+			"PATCH " + path:  pathItem.Patch,
+		}
+		if operation := operations[route]; operation != nil {
+			return operation
+		}
+	}
+	return nil
+}
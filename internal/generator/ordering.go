@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+// methodOrder es el orden canónico de las operaciones dentro de un path
+var methodOrder = map[string]int{
+	"GET":    0,
+	"PUT":    1,
+	"POST":   2,
+	"DELETE": 3,
+	"PATCH":  4,
+}
+
+// PropertyNames devuelve los nombres de las propiedades en el orden de declaración
+// del struct; las propiedades sin orden conocido se agregan al final en orden alfabético
+func (s Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	seen := make(map[string]bool, len(s.Properties))
+	for _, name := range s.propertyOrder {
+		if _, exists := s.Properties[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	for _, name := range sortedKeys(s.Properties) {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// orderedProperties serializa un mapa de propiedades respetando un orden dado
+type orderedProperties struct {
+	names   []string
+	schemas map[string]Schema
+}
+
+func (p *orderedProperties) MarshalJSON() ([]byte, error) {
+	return marshalInOrder(p.names, p.schemas)
+}
+
+// orderedExample es el ejemplo de un objeto, con las propiedades en orden de declaración
+type orderedExample struct {
+	names  []string
+	values map[string]interface{}
+}
+
+func (e *orderedExample) MarshalJSON() ([]byte, error) {
+	return marshalInOrder(e.names, e.values)
+}
+
+// marshalInOrder serializa las entradas del mapa como un objeto JSON en el orden de names
+func marshalInOrder[V any](names []string, values map[string]V) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(values[name])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// sortedRoutes ordena las rutas por path y método para que la generación no dependa
+// del orden en que se registran en el código
+func sortedRoutes(routes []internal.RouteDescription) []internal.RouteDescription {
+	sorted := make([]internal.RouteDescription, len(routes))
+	copy(sorted, routes)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return methodOrder[sorted[i].Method] < methodOrder[sorted[j].Method]
+	})
+	return sorted
+}
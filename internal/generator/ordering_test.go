@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const orderingSource = `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	Zeta  string ` + "`json:\"zeta\"`" + `
+	Alpha string ` + "`json:\"alpha\"`" + `
+	Mid   int    ` + "`json:\"mid\"`" + `
+}
+
+func GetUser(c *gin.Context)    { c.JSON(200, User{}) }
+func CreateUser(c *gin.Context) { c.JSON(201, User{}) }
+func UpdateUser(c *gin.Context) { c.JSON(200, User{}) }
+func ListUsers(c *gin.Context)  { c.JSON(200, []User{}) }
+
+func main() {
+	r := gin.Default()
+	ROUTES
+}
+`
+
+func TestDeterministicOutput(t *testing.T) {
+	routes := []string{
+		`r.POST("/users", CreateUser)`,
+		`r.PUT("/users/:id", UpdateUser)`,
+		`r.GET("/users/:id", GetUser)`,
+		`r.GET("/users", ListUsers)`,
+	}
+	reversed := []string{routes[3], routes[2], routes[1], routes[0]}
+
+	first, err := json.Marshal(generateFromSource(t, strings.Replace(orderingSource, "ROUTES", strings.Join(routes, "\n\t"), 1)))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	second, err := json.Marshal(generateFromSource(t, strings.Replace(orderingSource, "ROUTES", strings.Join(reversed, "\n\t"), 1)))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("Expected identical output regardless of route order:\n%s\n%s", first, second)
+	}
+
+	// Propiedades en el orden de declaración del struct
+	output := string(first)
+	properties := output[strings.Index(output, `"User":`):]
+	if !(strings.Index(properties, `"zeta"`) < strings.Index(properties, `"alpha"`) && strings.Index(properties, `"alpha"`) < strings.Index(properties, `"mid"`)) {
+		t.Errorf("Expected properties in declaration order, got %s", properties)
+	}
+
+	// Operaciones en el orden de la especificación (get, put, post, delete, patch)
+	item := output[strings.Index(output, `"/users/{id}":`):]
+	if strings.Index(item, `"get":`) > strings.Index(item, `"put":`) {
+		t.Errorf("Expected get before put, got %s", item)
+	}
+}
+
+func TestPropertyNames(t *testing.T) {
+	schema := Schema{
+		Properties:    map[string]Schema{"b": {}, "a": {}, "extra": {}, "c": {}},
+		propertyOrder: []string{"c", "a", "b", "removed"},
+	}
+
+	names := strings.Join(schema.PropertyNames(), ",")
+	if names != "c,a,b,extra" {
+		t.Errorf("Expected c,a,b,extra, got %s", names)
+	}
+}
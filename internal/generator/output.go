@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Formatos de salida soportados
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// FormatFromFilename elige el formato de salida según la extensión del archivo;
+// cualquier extensión distinta de .yaml/.yml se escribe como JSON
+func FormatFromFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// EncodeSpec convierte la especificación JSON al formato pedido, conservando el orden de las claves
+func EncodeSpec(data []byte, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return data, nil
+	case FormatYAML:
+		return yaml.JSONToYAML(data)
+	default:
+		return nil, fmt.Errorf("unknown output format %q (use %s or %s)", format, FormatJSON, FormatYAML)
+	}
+}
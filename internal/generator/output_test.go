@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOutputFormats(t *testing.T) {
+	tests := map[string]string{
+		"openapi.json":     FormatJSON,
+		"openapi.yaml":     FormatYAML,
+		"docs/openapi.YML": FormatYAML,
+		"openapi":          FormatJSON,
+		"-":                FormatJSON,
+	}
+	for filename, expected := range tests {
+		if format := FormatFromFilename(filename); format != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, filename, format)
+		}
+	}
+
+	data := []byte(`{"openapi":"3.0.3","info":{"title":"Test API","version":"1.0.0"},"paths":{}}`)
+	output, err := EncodeSpec(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("EncodeSpec failed: %v", err)
+	}
+
+	// Las claves conservan el orden del JSON
+	yaml := string(output)
+	if !strings.HasPrefix(yaml, "openapi: 3.0.3\n") || strings.Index(yaml, "info:") > strings.Index(yaml, "paths:") {
+		t.Errorf("Expected YAML in JSON key order, got:\n%s", yaml)
+	}
+	if !strings.Contains(yaml, "  title: Test API\n") {
+		t.Errorf("Expected nested title in YAML, got:\n%s", yaml)
+	}
+
+	if _, err := EncodeSpec(data, "xml"); err == nil {
+		t.Errorf("Expected error for unknown format")
+	}
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"fmt"
+	"go/types"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+	"github.com/Larry-Baltodano/go-auto-swagger/internal/handler"
+)
+
+const responsesPrefix = "#/components/responses/"
+
+// generateDefaultResponses declara en components.responses las respuestas globales
+// configuradas que alguna operación referencia, con el tipo de error del proyecto
+func (g *OpenAPIGenerator) generateDefaultResponses(spec *OpenAPISpec) {
+	used := make(map[string]bool)
+	for _, pathItem := range spec.Paths {
+		for _, operation := range pathItem.operations() {
+			for _, response := range operation.Responses {
+				if response.Ref != "" {
+					used[strings.TrimPrefix(response.Ref, responsesPrefix)] = true
+				}
+			}
+		}
+	}
+
+	if len(used) == 0 {
+		return
+	}
+
+	for _, statusCode := range g.Config.DefaultResponses {
+		name := responseComponentName(statusCode)
+		if !used[name] {
+			continue
+		}
+
+		response := Response{Description: http.StatusText(statusCode)}
+		if g.envelope != nil {
+			response.Content = map[string]MediaType{
+				"application/json": {
+					Schema: g.schemas.SchemaFor(g.envelope),
+				},
+			}
+		}
+
+		if spec.Components.Responses == nil {
+			spec.Components.Responses = make(map[string]Response)
+		}
+		spec.Components.Responses[name] = response
+	}
+}
+
+// errorEnvelope determina el tipo de error del proyecto: el configurado en ErrorType o,
+// si no, el struct que usan la mayoría de las respuestas de error escritas por los handlers
+func (g *OpenAPIGenerator) errorEnvelope(apiDesc *internal.APIDescription) types.Type {
+	if g.Config.ErrorType != "" {
+		if named := g.coordinator.HandlerAnalyzer.LookupType(g.Config.ErrorType); named != nil {
+			return named
+		}
+		g.schemas.diagnostics = append(g.schemas.diagnostics, fmt.Sprintf("error type %s not found in the analyzed packages", g.Config.ErrorType))
+		return nil
+	}
+
+	counts := make(map[string]int)
+	candidates := make(map[string]types.Type)
+	total := 0
+	for _, route := range apiDesc.Routes {
+		if route.HandlerInfo == nil {
+			continue
+		}
+
+		for _, response := range route.HandlerInfo.Responses {
+			if response.StatusCode < 400 || response.GoType == nil {
+				continue
+			}
+			total++
+
+			goType := response.GoType
+			if pointer, ok := goType.(*types.Pointer); ok {
+				goType = pointer.Elem()
+			}
+			if named, ok := goType.(*types.Named); ok {
+				if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+					key := types.TypeString(named, nil)
+					counts[key]++
+					candidates[key] = named
+				}
+			}
+		}
+	}
+
+	// Debe ser el tipo de la mayoría de las respuestas de error con cuerpo
+	best := ""
+	for _, key := range sortedKeys(counts) {
+		if counts[key] > counts[best] {
+			best = key
+		}
+	}
+	if best == "" || counts[best]*2 <= total {
+		return nil
+	}
+	return candidates[best]
+}
+
+// responseComponentName nombra una respuesta global según su código (500 → InternalServerError)
+func responseComponentName(statusCode int) string {
+	text := http.StatusText(statusCode)
+	if text == "" {
+		return "Status" + strconv.Itoa(statusCode)
+	}
+
+	var name strings.Builder
+	text = strings.ReplaceAll(text, "'", "") // I'm a teapot → ImATeapot
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		name.WriteString(upperFirst(word))
+	}
+	return name.String()
+}
+
+// hasSuccessResponse indica si alguna respuesta tiene código 2xx, es una redirección 3xx
+// o el 101 de un WebSocket
+func hasSuccessResponse(responses map[string]Response) bool {
+	for code := range responses {
+		statusCode, err := strconv.Atoi(code)
+		if err == nil && (statusCode >= 200 && statusCode < 400 || statusCode == http.StatusSwitchingProtocols) {
+			return true
+		}
+	}
+	return false
+}
+
+// responseSchema construye el schema del cuerpo escrito: los cuerpos binarios (archivos,
+// c.Data, c.ProtoBuf) se documentan como string binario y los errores JSON sin tipo
+// (gin.H{"error": ...}) referencian el tipo de error del proyecto
+func (g *OpenAPIGenerator) responseSchema(response handler.ResponseInfo, contentType string) *Schema {
+	if len(response.Events) > 0 {
+		return g.eventStreamSchema(response.Events)
+	}
+	if !response.Binary {
+		schema := g.schemas.SchemaFor(response.GoType)
+		if response.StatusCode >= 400 && contentType == "application/json" && g.envelope != nil && isUntypedSchema(schema) {
+			return g.schemas.SchemaFor(g.envelope)
+		}
+		return schema
+	}
+
+	schema := &Schema{Type: "string", Format: "binary"}
+	if response.Type != "" && response.Type != "unknown" {
+		schema.Description = response.Type // Mensaje serializado (c.ProtoBuf)
+	}
+	return schema
+}
+
+// responseHeader documenta un header de respuesta con su valor constante como ejemplo
+func responseHeader(header handler.HeaderInfo) Header {
+	schema := &Schema{Type: "string"}
+	if header.Value != "" {
+		schema.Example = header.Value
+	}
+	return Header{Schema: schema}
+}
+
+// eventStreamSchema documenta los eventos de un text/event-stream como objetos
+// {event, data}: el nombre del evento como enum y el payload con su schema; si el
+// handler envía varios eventos se combinan con oneOf
+func (g *OpenAPIGenerator) eventStreamSchema(events []handler.EventInfo) *Schema {
+	schemas := make([]Schema, 0, len(events))
+	for _, event := range events {
+		name := Schema{Type: "string"}
+		if event.Name != "" {
+			name.Enum = []interface{}{event.Name}
+		}
+
+		data := &Schema{Type: "string"}
+		if event.GoType != nil {
+			data = g.schemas.SchemaFor(event.GoType)
+		}
+
+		schemas = append(schemas, Schema{
+			Type:          "object",
+			Properties:    map[string]Schema{"event": name, "data": *data},
+			Required:      []string{"event", "data"},
+			propertyOrder: []string{"event", "data"},
+		})
+	}
+
+	if len(schemas) == 1 {
+		return &schemas[0]
+	}
+	return &Schema{OneOf: schemas}
+}
+
+// isUntypedSchema indica si el schema no describe la forma del cuerpo (gin.H, map[string]any)
+func isUntypedSchema(schema *Schema) bool {
+	if schema.Ref != "" || schema.Type != "object" || len(schema.Properties) > 0 {
+		return false
+	}
+	additional := schema.AdditionalProperties
+	return additional == nil || (additional.Type == "" && additional.Ref == "" && len(additional.OneOf) == 0)
+}
@@ -0,0 +1,585 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestErrorResponsesFromHandler(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type ValidationError struct {
+	Field string ` + "`json:\"field\"`" + `
+}
+
+func CreateUser(c *gin.Context) {
+	var user User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, ValidationError{})
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+func DeleteUser(c *gin.Context) {
+	c.AbortWithStatus(http.StatusNotFound)
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/users", CreateUser)
+	r.DELETE("/users/:id", DeleteUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	post := spec.Paths["/users"].Post.Responses
+	for _, code := range []string{"400", "401", "403", "404"} {
+		if _, exists := post[code]; exists {
+			t.Errorf("Expected no blanket %s response, got %v", code, post[code])
+		}
+	}
+
+	unprocessable, exists := post["422"]
+	if !exists || unprocessable.Description != "Unprocessable Entity" {
+		t.Fatalf("Expected 422 response written by the handler, got %+v", post)
+	}
+	if ref := unprocessable.Content["application/json"].Schema.Ref; ref != "#/components/schemas/ValidationError" {
+		t.Errorf("Expected 422 to reference ValidationError, got %q", ref)
+	}
+
+	if ref := post["500"].Ref; ref != "#/components/responses/InternalServerError" {
+		t.Errorf("Expected default 500 to reference components.responses, got %+v", post["500"])
+	}
+	if _, exists := spec.Components.Responses["InternalServerError"]; !exists {
+		t.Errorf("Expected InternalServerError in components.responses, got %v", spec.Components.Responses)
+	}
+
+	// Una respuesta sin cuerpo no declara contenido y el éxito por defecto se mantiene
+	remove := spec.Paths["/users/{id}"].Delete.Responses
+	if notFound, exists := remove["404"]; !exists || notFound.Content != nil {
+		t.Errorf("Expected 404 without content, got %+v", remove["404"])
+	}
+	if _, exists := remove["204"]; !exists {
+		t.Errorf("Expected default 204 success response, got %v", remove)
+	}
+}
+
+func TestResponseComponentName(t *testing.T) {
+	tests := map[int]string{
+		500: "InternalServerError",
+		404: "NotFound",
+		418: "ImATeapot",
+		599: "Status599",
+	}
+	for statusCode, expected := range tests {
+		if name := responseComponentName(statusCode); name != expected {
+			t.Errorf("Expected %s for %d, got %s", expected, statusCode, name)
+		}
+	}
+}
+
+func TestErrorEnvelopeFromHelper(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Code    string ` + "`json:\"code\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+}
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+func respondError(c *gin.Context, status int, err error) {
+	c.AbortWithStatusJSON(status, ErrorResponse{Message: err.Error()})
+}
+
+func GetUser(c *gin.Context) {
+	var user User
+	if err := c.ShouldBindJSON(&user); err != nil {
+		respondError(c, http.StatusBadRequest, err)
+		return
+	}
+	respondError(c, http.StatusNotFound, nil)
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/users", GetUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	if _, exists := spec.Components.Schemas["Error"]; exists {
+		t.Errorf("Expected no hardcoded Error component, got %v", spec.Components.Schemas["Error"])
+	}
+
+	responses := spec.Paths["/users"].Post.Responses
+	for _, code := range []string{"400", "404"} {
+		response, exists := responses[code]
+		if !exists {
+			t.Fatalf("Expected %s response written through the helper, got %v", code, responses)
+		}
+		if ref := response.Content["application/json"].Schema.Ref; ref != "#/components/schemas/ErrorResponse" {
+			t.Errorf("Expected %s to reference ErrorResponse, got %q", code, ref)
+		}
+	}
+
+	internalError := spec.Components.Responses["InternalServerError"]
+	if internalError.Content == nil || internalError.Content["application/json"].Schema.Ref != "#/components/schemas/ErrorResponse" {
+		t.Errorf("Expected default 500 to use the detected envelope, got %+v", internalError)
+	}
+}
+
+func TestUntypedErrorsUseEnvelope(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Code    string ` + "`json:\"code\"`" + `
+	Message string ` + "`json:\"message\"`" + `
+}
+
+func GetUser(c *gin.Context) {
+	if c.Param("id") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing id"})
+		return
+	}
+	if c.Query("fail") != "" {
+		c.JSON(http.StatusConflict, map[string]string{"error": "conflict"})
+		return
+	}
+	c.JSON(http.StatusNotFound, ErrorResponse{})
+}
+
+func DeleteUser(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{})
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{})
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", GetUser)
+	r.DELETE("/users/:id", DeleteUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	// Los errores escritos con gin.H referencian el tipo de error del proyecto
+	get := findOperation(spec, "GET /users/{id}").Responses
+	if ref := get["400"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/ErrorResponse" {
+		t.Errorf("Expected gin.H 400 to reference ErrorResponse, got %+v", get["400"].Content["application/json"].Schema)
+	}
+
+	// Un mapa con valores tipados describe su forma y se mantiene
+	if conflict := get["409"].Content["application/json"].Schema; conflict.Ref != "" || conflict.AdditionalProperties == nil {
+		t.Errorf("Expected typed map on 409 to stay inline, got %+v", conflict)
+	}
+
+	// Las respuestas exitosas sin tipo no cambian
+	remove := findOperation(spec, "DELETE /users/{id}").Responses
+	if ok := remove["200"].Content["application/json"].Schema; ok.Ref != "" || ok.Type != "object" {
+		t.Errorf("Expected inline object on 200, got %+v", ok)
+	}
+}
+
+func TestConfiguredErrorType(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Problem struct {
+	Title  string ` + "`json:\"title\"`" + `
+	Status int    ` + "`json:\"status\"`" + `
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(404, gin.H{"error": "not found"})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", GetUser)
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	// Sin un tipo de error detectable la respuesta global no declara contenido
+	openapiGenerator := NewOpenAPIGenerator(coordinator)
+	spec := openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+	if content := spec.Components.Responses["InternalServerError"].Content; content != nil {
+		t.Errorf("Expected no content without an error envelope, got %v", content)
+	}
+
+	openapiGenerator.Config.ErrorType = "main.Problem"
+	spec = openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+	if ref := spec.Components.Responses["InternalServerError"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/Problem" {
+		t.Errorf("Expected configured Problem envelope, got %q", ref)
+	}
+
+	openapiGenerator.Config.ErrorType = "Missing"
+	openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+	if diagnostics := openapiGenerator.Diagnostics(); len(diagnostics) != 1 {
+		t.Errorf("Expected a diagnostic for an unknown error type, got %v", diagnostics)
+	}
+}
+
+func TestNonJSONResponses(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Report struct {
+	Title string ` + "`json:\"title\" xml:\"title\"`" + `
+}
+
+func GetReport(c *gin.Context) {
+	c.Negotiate(http.StatusOK, gin.Negotiate{
+		Offered: []string{gin.MIMEJSON, gin.MIMEXML, "application/x-yaml"},
+		Data:    Report{},
+	})
+}
+
+func GetReportXML(c *gin.Context) {
+	if c.Query("fail") != "" {
+		c.String(http.StatusBadRequest, "invalid report %s", c.Query("fail"))
+		return
+	}
+	c.XML(http.StatusOK, Report{})
+}
+
+func GetPage(c *gin.Context) {
+	c.HTML(http.StatusOK, "report.tmpl", gin.H{})
+}
+
+func DownloadReport(c *gin.Context) {
+	c.FileAttachment("./reports/latest.pdf", "report.pdf")
+}
+
+func GetLogo(c *gin.Context) {
+	c.Data(http.StatusOK, "image/png; charset=binary", []byte{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/report", GetReport)
+	r.GET("/report.xml", GetReportXML)
+	r.GET("/page", GetPage)
+	r.GET("/report/download", DownloadReport)
+	r.GET("/logo", GetLogo)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	// c.Negotiate documenta todos los formatos ofrecidos y el 406
+	negotiated := findOperation(spec, "GET /report").Responses
+	for _, contentType := range []string{"application/json", "application/xml", "application/x-yaml"} {
+		media, exists := negotiated["200"].Content[contentType]
+		if !exists || media.Schema == nil || media.Schema.Ref != "#/components/schemas/Report" {
+			t.Errorf("Expected %s with Report schema, got %+v", contentType, negotiated["200"].Content)
+		}
+	}
+	if _, exists := negotiated["406"]; !exists {
+		t.Errorf("Expected 406 response from c.Negotiate, got %v", negotiated)
+	}
+
+	xml := findOperation(spec, "GET /report.xml").Responses
+	if len(xml["200"].Content) != 1 || xml["200"].Content["application/xml"].Schema == nil {
+		t.Errorf("Expected only application/xml on 200, got %v", xml["200"].Content)
+	}
+	if text := xml["400"].Content["text/plain"].Schema; text == nil || text.Type != "string" {
+		t.Errorf("Expected text/plain string on 400, got %v", xml["400"].Content)
+	}
+
+	if html := findOperation(spec, "GET /page").Responses["200"].Content["text/html"].Schema; html == nil || html.Type != "string" {
+		t.Errorf("Expected text/html string, got %v", findOperation(spec, "GET /page").Responses)
+	}
+
+	// Las descargas son binarias y declaran Content-Disposition
+	download := findOperation(spec, "GET /report/download").Responses["200"]
+	if pdf := download.Content["application/pdf"].Schema; pdf == nil || pdf.Format != "binary" {
+		t.Errorf("Expected binary application/pdf, got %v", download.Content)
+	}
+	disposition, exists := download.Headers["Content-Disposition"]
+	if !exists || disposition.Schema.Example != `attachment; filename="report.pdf"` {
+		t.Errorf("Expected Content-Disposition header with the file name, got %+v", download.Headers)
+	}
+
+	if png := findOperation(spec, "GET /logo").Responses["200"].Content["image/png"].Schema; png == nil || png.Format != "binary" {
+		t.Errorf("Expected binary image/png, got %v", findOperation(spec, "GET /logo").Responses)
+	}
+}
+
+func TestServerSentEvents(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Progress struct {
+	Percent int ` + "`json:\"percent\"`" + `
+}
+
+const progressEvent = "progress"
+
+func WatchJob(c *gin.Context) {
+	c.Stream(func(w io.Writer) bool {
+		c.SSEvent(progressEvent, Progress{})
+		c.SSEvent("done", "finished")
+		return false
+	})
+}
+
+func Tail(c *gin.Context) {
+	for range time.Tick(time.Second) {
+		c.Writer.Write([]byte("data: tick\n\n"))
+		c.Writer.Flush()
+	}
+	c.Status(http.StatusOK)
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/jobs/:id/events", WatchJob)
+	r.GET("/tail", Tail)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	watch := findOperation(spec, "GET /jobs/{id}/events").Responses["200"]
+	stream := watch.Content["text/event-stream"].Schema
+	if stream == nil || len(stream.OneOf) != 2 {
+		t.Fatalf("Expected text/event-stream with 2 events, got %+v", watch.Content)
+	}
+	progress := stream.OneOf[0]
+	if enum := progress.Properties["event"].Enum; len(enum) != 1 || enum[0] != "progress" {
+		t.Errorf("Expected progress event name, got %v", enum)
+	}
+	if ref := progress.Properties["data"].Ref; ref != "#/components/schemas/Progress" {
+		t.Errorf("Expected progress data to reference Progress, got %q", ref)
+	}
+	if data := stream.OneOf[1].Properties["data"]; data.Type != "string" {
+		t.Errorf("Expected string payload for done event, got %+v", data)
+	}
+
+	// c.Writer.Flush() sin eventos conocidos documenta el stream como texto
+	tail := findOperation(spec, "GET /tail").Responses["200"].Content
+	if schema := tail["text/event-stream"].Schema; schema == nil || schema.Type != "string" {
+		t.Errorf("Expected text/event-stream string, got %v", tail)
+	}
+}
+
+func TestRedirects(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const loginPath = "/login"
+
+func Logout(c *gin.Context) {
+	c.Redirect(http.StatusFound, loginPath)
+}
+
+func LegacyUser(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, "/v2/users/"+c.Param("id"))
+}
+
+func LegacyOrder(c *gin.Context) {
+	orderID := c.Param("id")
+	c.Redirect(http.StatusTemporaryRedirect, fmt.Sprintf("/v2/orders/%s/items", orderID))
+}
+
+func Discount(c *gin.Context) {
+	code := http.StatusFound
+	if c.Query("permanent") != "" {
+		code = http.StatusMovedPermanently
+	}
+	c.Redirect(code, fmt.Sprintf("/sales/100%%/%s", c.Param("name")))
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/logout", Logout)
+	r.GET("/users/:id", LegacyUser)
+	r.GET("/orders/:id", LegacyOrder)
+	r.GET("/discounts/:name", Discount)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	logout := findOperation(spec, "POST /logout").Responses
+	found, exists := logout["302"]
+	if !exists || found.Description != "Found. Redirects to /login" {
+		t.Fatalf("Expected 302 redirect to /login, got %+v", logout)
+	}
+	if location := found.Headers["Location"].Schema; location == nil || location.Example != "/login" {
+		t.Errorf("Expected Location header with /login example, got %v", found.Headers)
+	}
+	if _, exists := logout["201"]; exists {
+		t.Errorf("Expected no default success response, got %v", logout)
+	}
+
+	moved := findOperation(spec, "GET /users/{id}").Responses["301"]
+	if moved.Description != "Moved Permanently. Redirects to /v2/users/{id}" {
+		t.Errorf("Expected redirect built from the route, got %q", moved.Description)
+	}
+	if _, exists := moved.Headers["Location"]; !exists {
+		t.Errorf("Expected Location header, got %v", moved.Headers)
+	}
+
+	temporary := findOperation(spec, "GET /orders/{id}").Responses["307"]
+	if temporary.Description != "Temporary Redirect. Redirects to /v2/orders/{orderID}/items" {
+		t.Errorf("Expected redirect built with Sprintf, got %q", temporary.Description)
+	}
+
+	// Un código que no es constante se documenta como 302; %% es un % literal
+	discount := findOperation(spec, "GET /discounts/{name}").Responses
+	if _, exists := discount["200"]; exists {
+		t.Errorf("Expected no default 200 response, got %v", discount)
+	}
+	if redirect := discount["302"]; redirect.Description != "Found. Redirects to /sales/100%/{name}" {
+		t.Errorf("Expected 302 redirect with a literal %%, got %+v", discount)
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+func RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-RateLimit-Limit", "100")
+		c.Next()
+	}
+}
+
+func ListUsers(c *gin.Context) {
+	if c.Query("page") == "0" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return
+	}
+	setPagination(c, 42)
+	c.JSON(http.StatusOK, []User{})
+}
+
+func setPagination(c *gin.Context, total int) {
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.Writer.Header().Add("Link", "</users?page=2>; rel=\"next\"")
+}
+
+func GetUser(c *gin.Context) {
+	c.Writer.Header().Set("ETag", "\"v1\"")
+	c.Writer.Header().Set("Content-Type", "application/json")
+	c.JSON(http.StatusOK, User{})
+}
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api", RateLimit())
+	api.GET("/users", ListUsers)
+	api.GET("/users/:id", GetUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	list := findOperation(spec, "GET /users").Responses
+	for _, name := range []string{"X-Total-Count", "Link", "X-RateLimit-Limit"} {
+		if _, exists := list["200"].Headers[name]; !exists {
+			t.Errorf("Expected %s header on 200, got %v", name, list["200"].Headers)
+		}
+	}
+
+	// Los headers del handler solo acompañan a las respuestas escritas después
+	if _, exists := list["400"].Headers["X-Total-Count"]; exists {
+		t.Errorf("Expected no X-Total-Count on 400, got %v", list["400"].Headers)
+	}
+	if limit := list["400"].Headers["X-RateLimit-Limit"].Schema; limit == nil || limit.Example != "100" {
+		t.Errorf("Expected middleware X-RateLimit-Limit on 400, got %v", list["400"].Headers)
+	}
+
+	get := findOperation(spec, "GET /users/{id}").Responses["200"]
+	if etag := get.Headers["ETag"].Schema; etag == nil || etag.Example != "\"v1\"" {
+		t.Errorf("Expected ETag header with example, got %v", get.Headers)
+	}
+	if _, exists := get.Headers["Content-Type"]; exists {
+		t.Errorf("Expected Content-Type to be documented as media type only, got %v", get.Headers)
+	}
+}
@@ -0,0 +1,479 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal/handler"
+)
+
+// SchemaBuilder convierte tipos Go en schemas OpenAPI.
+// Los structs con nombre se registran como componentes y se referencian con $ref.
+// El nombre se reserva antes de recorrer los campos, así un tipo recursivo
+// (Category{Children []Category}) termina en un $ref al componente en construcción.
+// Los genéricos instanciados (Page[User]) generan un componente por instanciación.
+// Durante la construcción los componentes se identifican por su tipo calificado;
+// los nombres finales se asignan al final con ResolveNames.
+type SchemaBuilder struct {
+	schemas         map[string]Schema       // Tipo calificado → schema
+	types           map[string]*types.Named // Tipo calificado → tipo registrado
+	names           map[string]string       // Tipo calificado → nombre final
+	renames         map[string]string
+	flattening      map[*types.Struct]bool
+	inlining        map[string]bool // Tipos sin struct en construcción → usados recursivamente
+	genericNaming   GenericNaming
+	componentNaming string
+	source          TypeSource
+	diagnostics     []string
+}
+
+// TypeSource proporciona información de los paquetes analizados
+type TypeSource interface {
+	// Implementations devuelve los tipos concretos conocidos que implementan una interfaz
+	Implementations(iface *types.Interface) []*types.Named
+	// Doc devuelve el comentario de documentación de la declaración en pos
+	Doc(pos token.Pos) string
+	// Constants devuelve las constantes declaradas con el tipo indicado, en orden de declaración
+	Constants(named *types.Named) []*types.Const
+}
+
+// discriminatorCandidates son los nombres de campo que se consideran etiquetas de tipo
+var discriminatorCandidates = []string{"type", "kind", "@type", "_type"}
+
+// NewSchemaBuilder crea un constructor de schemas vacío
+func NewSchemaBuilder(config Config, source TypeSource) *SchemaBuilder {
+	genericNaming, exists := GenericNamingStrategies[config.GenericNaming]
+	if !exists {
+		genericNaming = GenericNamingStrategies["underscore"]
+	}
+
+	componentNaming := config.ComponentNaming
+	if componentNaming == "" {
+		componentNaming = DefaultConfig().ComponentNaming
+	}
+
+	return &SchemaBuilder{
+		schemas:         make(map[string]Schema),
+		types:           make(map[string]*types.Named),
+		names:           make(map[string]string),
+		renames:         make(map[string]string),
+		flattening:      make(map[*types.Struct]bool),
+		inlining:        make(map[string]bool),
+		genericNaming:   genericNaming,
+		componentNaming: componentNaming,
+		source:          source,
+	}
+}
+
+// Schemas devuelve los componentes con sus nombres finales (requiere ResolveNames)
+func (b *SchemaBuilder) Schemas() map[string]Schema {
+	schemas := make(map[string]Schema, len(b.schemas))
+	for key, schema := range b.schemas {
+		schemas[b.names[key]] = schema
+	}
+	return schemas
+}
+
+// Diagnostics devuelve los avisos producidos al construir los schemas
+func (b *SchemaBuilder) Diagnostics() []string {
+	return b.diagnostics
+}
+
+// SchemaFor construye el schema de un tipo Go
+func (b *SchemaBuilder) SchemaFor(t types.Type) *Schema {
+	switch t := t.(type) {
+	case nil:
+		return &Schema{Type: "object"}
+	case *types.Alias:
+		return b.SchemaFor(types.Unalias(t))
+	case *types.Named:
+		return b.namedSchema(t)
+	case *types.Pointer:
+		return b.SchemaFor(t.Elem())
+	case *types.Slice:
+		return b.arraySchema(t.Elem())
+	case *types.Array:
+		return b.arraySchema(t.Elem())
+	case *types.Map:
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: b.SchemaFor(t.Elem()),
+		}
+	case *types.Struct:
+		return b.structSchema(t)
+	case *types.Basic:
+		return basicSchema(t)
+	case *types.Interface:
+		if !t.Empty() {
+			b.diagnostics = append(b.diagnostics, fmt.Sprintf("anonymous interface %s cannot be resolved, documented as any value", types.TypeString(t, nil)))
+		}
+		return &Schema{} // Cualquier valor
+	case *types.TypeParam:
+		return &Schema{} // Cualquier valor
+	default:
+		return &Schema{Type: "object"}
+	}
+}
+
+// namedSchema registra un struct con nombre como componente y devuelve su $ref
+func (b *SchemaBuilder) namedSchema(named *types.Named) *Schema {
+	if mapping, exists := handler.TypeMapping[qualifiedName(named)]; exists {
+		return &Schema{Type: mapping.Type, Format: mapping.Format}
+	}
+
+	if iface, ok := named.Underlying().(*types.Interface); ok {
+		return b.interfaceSchema(named, iface)
+	}
+
+	key := types.TypeString(named, nil)
+	if _, exists := b.types[key]; exists {
+		// Ya construido o en construcción (tipo recursivo)
+		return refSchema(key)
+	}
+
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return b.inlineNamedSchema(named, key)
+	}
+
+	// Para instancias genéricas, Underlying ya tiene los argumentos de tipo sustituidos
+	b.types[key] = named
+	schema := b.structSchema(structType)
+	b.applyDoc(schema, named.Obj().Pos())
+	b.schemas[key] = *schema
+
+	return refSchema(key)
+}
+
+// inlineNamedSchema documenta en línea un tipo con nombre que no es struct (type Status string).
+// Si el tipo se usa a sí mismo (type Tree []Tree, type JSON map[string]JSON), el uso
+// recursivo se marca y el tipo se registra como componente para cerrar el ciclo con $ref.
+func (b *SchemaBuilder) inlineNamedSchema(named *types.Named, key string) *Schema {
+	if _, inProgress := b.inlining[key]; inProgress {
+		b.inlining[key] = true
+		return refSchema(key)
+	}
+
+	b.inlining[key] = false
+	schema := b.SchemaFor(named.Underlying())
+	recursive := b.inlining[key]
+	delete(b.inlining, key)
+
+	b.applyConstantEnum(schema, named)
+	if !recursive {
+		return schema
+	}
+
+	b.types[key] = named
+	b.applyDoc(schema, named.Obj().Pos())
+	b.schemas[key] = *schema
+	return refSchema(key)
+}
+
+// interfaceSchema registra una interfaz como componente oneOf sobre sus implementaciones
+func (b *SchemaBuilder) interfaceSchema(named *types.Named, iface *types.Interface) *Schema {
+	// error y las interfaces vacías aceptan cualquier valor
+	if named.Obj().Pkg() == nil || iface.Empty() {
+		return &Schema{}
+	}
+
+	key := types.TypeString(named, nil)
+	if _, exists := b.types[key]; exists {
+		return refSchema(key)
+	}
+
+	var implementations []*types.Named
+	if b.source != nil {
+		implementations = b.source.Implementations(iface)
+	}
+
+	if len(implementations) == 0 {
+		b.diagnostics = append(b.diagnostics, fmt.Sprintf("no implementations found for interface %s, documented as any value", key))
+		return &Schema{}
+	}
+
+	b.types[key] = named
+
+	schema := Schema{}
+	implementationKeys := make([]string, 0, len(implementations))
+	for _, implementation := range implementations {
+		ref := b.SchemaFor(implementation)
+		schema.OneOf = append(schema.OneOf, *ref)
+		implementationKeys = append(implementationKeys, strings.TrimPrefix(ref.Ref, componentsPrefix))
+	}
+
+	if property := b.sharedDiscriminator(implementationKeys); property != "" {
+		schema.Discriminator = &Discriminator{PropertyName: property}
+	}
+	b.applyDoc(&schema, named.Obj().Pos())
+
+	b.schemas[key] = schema
+	return refSchema(key)
+}
+
+// sharedDiscriminator busca un campo de tipo string con nombre de etiqueta presente en todas las implementaciones
+func (b *SchemaBuilder) sharedDiscriminator(componentKeys []string) string {
+	for _, candidate := range discriminatorCandidates {
+		shared := true
+		for _, key := range componentKeys {
+			component, exists := b.schemas[key]
+			if !exists || component.Properties[candidate].Type != "string" {
+				shared = false
+				break
+			}
+		}
+
+		if shared {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// structSchema construye un schema de objeto a partir de los campos exportados
+func (b *SchemaBuilder) structSchema(structType *types.Struct) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]Schema),
+	}
+	b.addStructFields(schema, structType)
+
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+	return schema
+}
+
+// addStructFields agrega los campos de un struct, aplanando los embebidos como encoding/json
+func (b *SchemaBuilder) addStructFields(schema *Schema, structType *types.Struct) {
+	b.flattening[structType] = true
+	defer delete(b.flattening, structType)
+
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		tag := reflect.StructTag(structType.Tag(i))
+
+		jsonName, jsonOptions, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+
+		if field.Embedded() && jsonName == "" {
+			if embedded, ok := embeddedStruct(field.Type()); ok {
+				if !b.flattening[embedded] {
+					b.addStructFields(schema, embedded)
+				}
+				continue
+			}
+		}
+
+		if !field.Exported() {
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = field.Name()
+		}
+
+		property := b.SchemaFor(field.Type())
+		if property.Ref == "" {
+			applyTagConstraints(property, tag)
+			applyTagExamples(property, tag)
+		} else {
+			property = b.refWithTags(property, tag)
+		}
+
+		if description, deprecated := b.docFor(field.Origin().Pos()); description != "" || deprecated {
+			// Los hermanos de $ref se ignoran en OpenAPI 3.0: envolver en allOf
+			if property.Ref != "" {
+				property = &Schema{AllOf: []Schema{*property}}
+			}
+			property.Description = description
+			property.Deprecated = deprecated
+		}
+
+		// Un puntero nil sin omitempty se serializa como null; solo se documenta en 3.1
+		_, isPointer := field.Type().(*types.Pointer)
+		property.nullable = isPointer && !strings.Contains(jsonOptions, "omitempty")
+
+		if _, exists := schema.Properties[jsonName]; !exists {
+			schema.propertyOrder = append(schema.propertyOrder, jsonName)
+		}
+		schema.Properties[jsonName] = *property
+		if isRequiredTag(tag) {
+			schema.Required = append(schema.Required, jsonName)
+		}
+	}
+}
+
+// refWithTags aplica los tags de validación y de ejemplo a un campo cuyo schema es un $ref.
+// Las reglas se interpretan con el tipo del componente y, como los hermanos de $ref se
+// ignoran en OpenAPI 3.0, el resultado envuelve la referencia en allOf.
+func (b *SchemaBuilder) refWithTags(ref *Schema, tag reflect.StructTag) *Schema {
+	componentType := b.componentType(strings.TrimPrefix(ref.Ref, componentsPrefix))
+	overlay := &Schema{Type: componentType}
+	applyTagConstraints(overlay, tag)
+	applyTagExamples(overlay, tag)
+
+	overlay.Type = ""
+	if reflect.DeepEqual(*overlay, Schema{}) {
+		return ref
+	}
+	overlay.AllOf = []Schema{*ref}
+	return overlay
+}
+
+// componentType devuelve el type de un componente, aunque todavía se esté construyendo
+func (b *SchemaBuilder) componentType(key string) string {
+	if component, exists := b.schemas[key]; exists {
+		return component.Type
+	}
+	if named, exists := b.types[key]; exists {
+		if _, ok := named.Underlying().(*types.Struct); ok {
+			return "object"
+		}
+	}
+	return ""
+}
+
+// BoundField es un campo de struct que gin enlaza con un tag distinto de json
+// (form, uri o header) y que se documenta como parámetro o propiedad de formulario
+type BoundField struct {
+	Name     string
+	Schema   *Schema
+	Required bool
+}
+
+// BoundFields devuelve los campos del struct según el tag de binding indicado; como gin,
+// usa el nombre del campo cuando no hay tag y aplana los structs embebidos
+func (b *SchemaBuilder) BoundFields(t types.Type, tagKey string) []BoundField {
+	structType, ok := embeddedStruct(t)
+	if !ok {
+		return nil
+	}
+
+	b.flattening[structType] = true
+	defer delete(b.flattening, structType)
+
+	var fields []BoundField
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		tag := reflect.StructTag(structType.Tag(i))
+
+		name, _, _ := strings.Cut(tag.Get(tagKey), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Embedded() && name == "" {
+			if embedded, ok := embeddedStruct(field.Type()); ok {
+				if !b.flattening[embedded] {
+					fields = append(fields, b.BoundFields(field.Type(), tagKey)...)
+				}
+				continue
+			}
+		}
+
+		if !field.Exported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+
+		schema := b.SchemaFor(field.Type())
+		if schema.Ref == "" {
+			applyTagConstraints(schema, tag)
+			applyTagExamples(schema, tag)
+			schema.Description, schema.Deprecated = b.docFor(field.Origin().Pos())
+		} else {
+			schema = b.refWithTags(schema, tag)
+		}
+
+		fields = append(fields, BoundField{Name: name, Schema: schema, Required: isRequiredTag(tag)})
+	}
+	return fields
+}
+
+// applyDoc copia el comentario de una declaración en la descripción del schema
+func (b *SchemaBuilder) applyDoc(schema *Schema, pos token.Pos) {
+	schema.Description, schema.Deprecated = b.docFor(pos)
+}
+
+// docFor obtiene la descripción y la marca "Deprecated:" de una declaración
+func (b *SchemaBuilder) docFor(pos token.Pos) (string, bool) {
+	if b.source == nil || !pos.IsValid() {
+		return "", false
+	}
+	return parseDocComment(b.source.Doc(pos))
+}
+
+// parseDocComment limpia un comentario y detecta el párrafo "Deprecated:" de la convención de Go
+func parseDocComment(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	deprecated := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "Deprecated:") {
+			deprecated = true
+			break
+		}
+	}
+	return text, deprecated
+}
+
+// arraySchema construye un schema de array; []byte se serializa como string base64
+func (b *SchemaBuilder) arraySchema(elem types.Type) *Schema {
+	if basic, ok := elem.(*types.Basic); ok && basic.Kind() == types.Byte {
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	return &Schema{
+		Type:  "array",
+		Items: b.SchemaFor(elem),
+	}
+}
+
+// basicSchema mapea los tipos básicos usando la tabla de tipos del analizador
+func basicSchema(basic *types.Basic) *Schema {
+	basic = types.Default(basic).(*types.Basic)
+	if mapping, exists := handler.TypeMapping[basic.Name()]; exists {
+		return &Schema{Type: mapping.Type, Format: mapping.Format}
+	}
+	return &Schema{Type: "object"}
+}
+
+// embeddedStruct obtiene el struct subyacente de un campo embebido
+func embeddedStruct(t types.Type) (*types.Struct, bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	structType, ok := t.Underlying().(*types.Struct)
+	return structType, ok
+}
+
+// qualifiedName devuelve el nombre con paquete corto (time.Time)
+func qualifiedName(named *types.Named) string {
+	if named.Obj().Pkg() == nil {
+		return named.Obj().Name()
+	}
+	return named.Obj().Pkg().Name() + "." + named.Obj().Name()
+}
+
+// isRequiredTag indica si los tags binding o validate marcan el campo como requerido
+func isRequiredTag(tag reflect.StructTag) bool {
+	for _, key := range []string{"binding", "validate"} {
+		for _, rule := range strings.Split(tag.Get(key), ",") {
+			if rule == "required" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func refSchema(key string) *Schema {
+	return &Schema{Ref: componentsPrefix + key}
+}
@@ -0,0 +1,440 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestRecursiveTypes(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Category struct {
+	ID       int        ` + "`json:\"id\"`" + `
+	Children []Category ` + "`json:\"children\"`" + `
+	Parent   *Category  ` + "`json:\"parent\"`" + `
+}
+
+type Comment struct {
+	Text   string  ` + "`json:\"text\"`" + `
+	Thread *Thread ` + "`json:\"thread\"`" + `
+}
+
+type Thread struct {
+	Comments []*Comment ` + "`json:\"comments\"`" + `
+}
+
+func GetCategory(c *gin.Context) {
+	c.JSON(200, Category{})
+}
+
+func CreateComment(c *gin.Context) {
+	var req Comment
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+	c.JSON(201, req)
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/categories/:id", GetCategory)
+	r.POST("/comments", CreateComment)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+	schemas := spec.Components.Schemas
+
+	category, exists := schemas["Category"]
+	if !exists {
+		t.Fatalf("Expected Category component, got %v", schemas)
+	}
+	if ref := category.Properties["children"].Items.Ref; ref != "#/components/schemas/Category" {
+		t.Errorf("Expected children items to reference Category, got %q", ref)
+	}
+	if ref := category.Properties["parent"].Ref; ref != "#/components/schemas/Category" {
+		t.Errorf("Expected parent to reference Category, got %q", ref)
+	}
+
+	for _, name := range []string{"Comment", "Thread"} {
+		if _, exists := schemas[name]; !exists {
+			t.Errorf("Expected %s component for mutually recursive types", name)
+		}
+	}
+	if ref := schemas["Thread"].Properties["comments"].Items.Ref; ref != "#/components/schemas/Comment" {
+		t.Errorf("Expected Thread.comments items to reference Comment, got %q", ref)
+	}
+
+	body := spec.Paths["/comments"].Post.RequestBody
+	if body == nil || body.Content["application/json"].Schema.Ref != "#/components/schemas/Comment" {
+		t.Errorf("Expected request body to reference Comment, got %+v", body)
+	}
+
+	response := spec.Paths["/categories/{id}"].Get.Responses["200"]
+	if response.Content["application/json"].Schema.Ref != "#/components/schemas/Category" {
+		t.Errorf("Expected 200 response to reference Category, got %+v", response)
+	}
+}
+
+func TestRecursiveNonStructTypes(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Tree []Tree
+
+type JSON map[string]JSON
+
+type Status string
+
+type Document struct {
+	Outline  Tree   ` + "`json:\"outline\"`" + `
+	Metadata JSON   ` + "`json:\"metadata\"`" + `
+	Status   Status ` + "`json:\"status\"`" + `
+}
+
+func GetDocument(c *gin.Context) {
+	c.JSON(200, Document{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/documents/:id", GetDocument)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+	schemas := spec.Components.Schemas
+
+	tree, exists := schemas["Tree"]
+	if !exists || tree.Type != "array" || tree.Items == nil || tree.Items.Ref != "#/components/schemas/Tree" {
+		t.Fatalf("Expected Tree component referencing itself, got %+v", tree)
+	}
+	json, exists := schemas["JSON"]
+	if !exists || json.AdditionalProperties == nil || json.AdditionalProperties.Ref != "#/components/schemas/JSON" {
+		t.Fatalf("Expected JSON component referencing itself, got %+v", json)
+	}
+
+	document := schemas["Document"]
+	if ref := document.Properties["outline"].Ref; ref != "#/components/schemas/Tree" {
+		t.Errorf("Expected outline to reference Tree, got %q", ref)
+	}
+
+	// Los tipos sin recursión siguen en línea
+	if _, exists := schemas["Status"]; exists {
+		t.Errorf("Expected Status inline, got component %+v", schemas["Status"])
+	}
+	if status := document.Properties["status"]; status.Type != "string" {
+		t.Errorf("Expected inline string status, got %+v", status)
+	}
+}
+
+func TestGenericInstantiations(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Page[T any] struct {
+	Items []T ` + "`json:\"items\"`" + `
+	Total int ` + "`json:\"total\"`" + `
+}
+
+type Response[T any] struct {
+	Data T ` + "`json:\"data\"`" + `
+}
+
+func ListUsers(c *gin.Context) {
+	c.JSON(200, Response[Page[User]]{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users", ListUsers)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+	schemas := spec.Components.Schemas
+
+	page, exists := schemas["Page_User"]
+	if !exists {
+		t.Fatalf("Expected Page_User component, got %v", schemas)
+	}
+	if ref := page.Properties["items"].Items.Ref; ref != "#/components/schemas/User" {
+		t.Errorf("Expected Page_User.items to reference User, got %q", ref)
+	}
+
+	response, exists := schemas["Response_Page_User"]
+	if !exists {
+		t.Fatalf("Expected Response_Page_User component, got %v", schemas)
+	}
+	if ref := response.Properties["data"].Ref; ref != "#/components/schemas/Page_User" {
+		t.Errorf("Expected Response_Page_User.data to reference Page_User, got %q", ref)
+	}
+
+	builder := NewSchemaBuilder(Config{GenericNaming: "of"}, nil)
+	for _, base := range []string{"Page", "Response"} {
+		if name := builder.genericNaming(base, []string{"User"}); name != base+"OfUser" {
+			t.Errorf("Expected %sOfUser with 'of' naming, got %s", base, name)
+		}
+	}
+}
+
+func TestInterfaceFieldsAsOneOf(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type PaymentMethod interface {
+	Charge(amount float64) error
+}
+
+type Notifier interface {
+	Notify() error
+}
+
+type CardPayment struct {
+	Type   string ` + "`json:\"type\"`" + `
+	Number string ` + "`json:\"number\"`" + `
+}
+
+func (p CardPayment) Charge(amount float64) error { return nil }
+
+type BankTransfer struct {
+	Type string ` + "`json:\"type\"`" + `
+	IBAN string ` + "`json:\"iban\"`" + `
+}
+
+func (p *BankTransfer) Charge(amount float64) error { return nil }
+
+type Order struct {
+	Payment  PaymentMethod              ` + "`json:\"payment\"`" + `
+	Notifier Notifier                   ` + "`json:\"notifier\"`" + `
+	Callback interface{ Call() error }  ` + "`json:\"callback\"`" + `
+	Metadata any                        ` + "`json:\"metadata\"`" + `
+}
+
+func CreateOrder(c *gin.Context) {
+	c.JSON(201, Order{})
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/orders", CreateOrder)
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	openapiGenerator := NewOpenAPIGenerator(coordinator)
+	spec := openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+	schemas := spec.Components.Schemas
+
+	order := schemas["Order"]
+	if ref := order.Properties["payment"].Ref; ref != "#/components/schemas/PaymentMethod" {
+		t.Fatalf("Expected payment to reference PaymentMethod, got %q", ref)
+	}
+
+	payment := schemas["PaymentMethod"]
+	if len(payment.OneOf) != 2 {
+		t.Fatalf("Expected 2 implementations in oneOf, got %+v", payment.OneOf)
+	}
+	if payment.OneOf[0].Ref != "#/components/schemas/BankTransfer" || payment.OneOf[1].Ref != "#/components/schemas/CardPayment" {
+		t.Errorf("Unexpected oneOf references: %+v", payment.OneOf)
+	}
+	if payment.Discriminator == nil || payment.Discriminator.PropertyName != "type" {
+		t.Errorf("Expected discriminator on 'type', got %+v", payment.Discriminator)
+	}
+
+	notifier := order.Properties["notifier"]
+	if notifier.Ref != "" || notifier.Type != "" || len(notifier.OneOf) != 0 {
+		t.Errorf("Expected empty schema for interface without implementations, got %+v", notifier)
+	}
+
+	// Las interfaces anónimas no vacías también avisan; any es intencionadamente libre
+	if callback := order.Properties["callback"]; callback.Ref != "" || callback.Type != "" {
+		t.Errorf("Expected empty schema for anonymous interface, got %+v", callback)
+	}
+	if len(openapiGenerator.Diagnostics()) != 2 {
+		t.Errorf("Expected two diagnostics, got %v", openapiGenerator.Diagnostics())
+	}
+}
+
+func TestInterfaceImplementationsInOtherPackages(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"payment/payment.go": `package payment
+
+type Method interface {
+	Charge(amount float64) error
+}
+`,
+		"payment/card/card.go": `package card
+
+type Card struct {
+	Number string ` + "`json:\"number\"`" + `
+}
+
+func (c Card) Charge(amount float64) error { return nil }
+`,
+		"checkout/checkout.go": `package checkout
+
+import "example.com/app/payment/card"
+
+var Default = card.Card{}
+`,
+		"cmd/fake/main.go": `package main
+
+type Fake struct{}
+
+func (f Fake) Charge(amount float64) error { return nil }
+
+func main() {}
+`,
+		"main.go": `package main
+
+import (
+	"example.com/app/checkout"
+	"example.com/app/payment"
+	"github.com/gin-gonic/gin"
+)
+
+var _ = checkout.Default
+
+type Order struct {
+	Payment payment.Method ` + "`json:\"payment\"`" + `
+}
+
+func CreateOrder(c *gin.Context) {
+	c.JSON(201, Order{})
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/orders", CreateOrder)
+}
+`,
+	}
+
+	tempDir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	openapiGenerator := NewOpenAPIGenerator(coordinator)
+	spec := openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+
+	// card se importa a través de checkout; los main de otros binarios no cuentan
+	method := spec.Components.Schemas["Method"]
+	if len(method.OneOf) != 1 || method.OneOf[0].Ref != "#/components/schemas/Card" {
+		t.Errorf("Expected Method oneOf [Card], got %+v", method.OneOf)
+	}
+	if diagnostics := openapiGenerator.Diagnostics(); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+}
+
+func TestDocCommentsAsDescriptions(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+// Address es una dirección postal
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// User representa un usuario completo
+type User struct {
+	// Nombre visible del usuario
+	Name string ` + "`json:\"name\"`" + `
+	Email string ` + "`json:\"email\"`" + ` // Correo de contacto
+	// Deprecated: usar Email
+	Mail string ` + "`json:\"mail\"`" + `
+	// Dirección principal
+	Address Address ` + "`json:\"address\"`" + `
+}
+
+// LegacyUser es la versión anterior de User.
+//
+// Deprecated: usar User.
+type LegacyUser struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, User{})
+}
+
+func GetLegacyUser(c *gin.Context) {
+	c.JSON(200, LegacyUser{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/users/:id", GetUser)
+	r.GET("/legacy/users/:id", GetLegacyUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+	schemas := spec.Components.Schemas
+
+	user := schemas["User"]
+	if user.Description != "User representa un usuario completo" {
+		t.Errorf("Unexpected User description: %q", user.Description)
+	}
+	if user.Properties["name"].Description != "Nombre visible del usuario" {
+		t.Errorf("Unexpected name description: %q", user.Properties["name"].Description)
+	}
+	if user.Properties["email"].Description != "Correo de contacto" {
+		t.Errorf("Unexpected email description from line comment: %q", user.Properties["email"].Description)
+	}
+	if !user.Properties["mail"].Deprecated {
+		t.Errorf("Expected mail to be deprecated")
+	}
+
+	address := user.Properties["address"]
+	if len(address.AllOf) != 1 || address.AllOf[0].Ref != "#/components/schemas/Address" || address.Description != "Dirección principal" {
+		t.Errorf("Expected described $ref wrapped in allOf, got %+v", address)
+	}
+
+	if legacy := schemas["LegacyUser"]; !legacy.Deprecated {
+		t.Errorf("Expected LegacyUser to be deprecated, got %+v", legacy)
+	}
+}
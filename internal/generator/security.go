@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+	"github.com/Larry-Baltodano/go-auto-swagger/internal/handler"
+)
+
+// oauth2SchemeName es el nombre del esquema que reciben los scopes de los middlewares
+const oauth2SchemeName = "oauth2"
+
+// SecurityScheme describe un esquema de components.securitySchemes
+type SecurityScheme struct {
+	Type        string      `json:"type"`             // http, apiKey, oauth2
+	Scheme      string      `json:"scheme,omitempty"` // basic, bearer (type: http)
+	In          string      `json:"in,omitempty"`     // header, query, cookie (type: apiKey)
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Flows       *OAuthFlows `json:"flows,omitempty"` // type: oauth2
+}
+
+// OAuthFlows contiene el flujo configurado de un esquema oauth2
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+}
+
+// OAuthFlow describe los endpoints y los scopes disponibles de un flujo OAuth2
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// SecurityRequirement asocia cada esquema exigido con sus scopes
+type SecurityRequirement map[string][]string
+
+// generateSecurity construye los requisitos de seguridad de una ruta. Cada middleware
+// aporta alternativas (OR) y todos los middlewares se exigen a la vez (AND), así que el
+// resultado es el producto de las alternativas. Los scopes de los middlewares
+// configurados se exigen sobre el esquema oauth2, que reemplaza al token Bearer.
+func (g *OpenAPIGenerator) generateSecurity(route internal.RouteDescription) []SecurityRequirement {
+	scopes := middlewareArguments(route, g.Config.ScopeMiddleware)
+	if len(route.Security) == 0 && len(scopes) == 0 {
+		return nil
+	}
+
+	requirements := []SecurityRequirement{{}}
+	for _, alternatives := range route.Security {
+		var combined []SecurityRequirement
+		for _, requirement := range requirements {
+			for _, info := range alternatives {
+				next := SecurityRequirement{}
+				for name, scopes := range requirement {
+					next[name] = scopes
+				}
+				if info.Type == "bearer" && len(scopes) > 0 {
+					next[g.registerOAuth2Scopes(scopes)] = scopes
+				} else {
+					next[g.registerSecurityScheme(info)] = []string{}
+				}
+				combined = append(combined, next)
+			}
+		}
+		requirements = combined
+	}
+
+	// Los scopes sin un middleware Bearer reconocido se exigen además de lo demás
+	if len(scopes) > 0 {
+		for _, requirement := range requirements {
+			requirement[g.registerOAuth2Scopes(scopes)] = scopes
+		}
+	}
+	return requirements
+}
+
+// registerSecurityScheme declara el esquema y devuelve su nombre: basicAuth, bearerAuth
+// o el nombre del header/parámetro de la API key
+func (g *OpenAPIGenerator) registerSecurityScheme(info handler.SecurityInfo) string {
+	var name string
+	var scheme SecurityScheme
+	switch info.Type {
+	case "basic":
+		name, scheme = "basicAuth", SecurityScheme{Type: "http", Scheme: "basic"}
+	case "bearer":
+		name, scheme = "bearerAuth", SecurityScheme{Type: "http", Scheme: "bearer"}
+	default:
+		name, scheme = info.Name, SecurityScheme{Type: "apiKey", In: info.In, Name: info.Name}
+	}
+
+	// La misma clave leída del header y de la query son esquemas distintos
+	if existing, exists := g.securitySchemes[name]; exists && existing != scheme {
+		name += "_" + info.In
+	}
+
+	g.securitySchemes[name] = scheme
+	return name
+}
+
+// registerOAuth2Scopes declara el esquema oauth2 con el flujo configurado y agrega los
+// scopes a los disponibles
+func (g *OpenAPIGenerator) registerOAuth2Scopes(scopes []string) string {
+	scheme, exists := g.securitySchemes[oauth2SchemeName]
+	if !exists {
+		flow := &OAuthFlow{
+			AuthorizationURL: g.Config.OAuth2AuthorizationURL,
+			TokenURL:         g.Config.OAuth2TokenURL,
+			Scopes:           make(map[string]string),
+		}
+
+		flows := &OAuthFlows{}
+		switch {
+		case flow.AuthorizationURL != "" && flow.TokenURL != "":
+			flows.AuthorizationCode = flow
+		case flow.AuthorizationURL != "":
+			flows.Implicit = flow
+		default:
+			flows.ClientCredentials = flow
+		}
+
+		scheme = SecurityScheme{Type: "oauth2", Flows: flows}
+		g.securitySchemes[oauth2SchemeName] = scheme
+	}
+
+	for _, scope := range scopes {
+		scheme.Flows.flow().Scopes[scope] = ""
+	}
+	return oauth2SchemeName
+}
+
+// flow devuelve el único flujo declarado
+func (f *OAuthFlows) flow() *OAuthFlow {
+	switch {
+	case f.AuthorizationCode != nil:
+		return f.AuthorizationCode
+	case f.Implicit != nil:
+		return f.Implicit
+	default:
+		return f.ClientCredentials
+	}
+}
+
+// middlewareArguments reúne los argumentos de los middlewares de la ruta cuyo
+// constructor está en la lista configurada
+func middlewareArguments(route internal.RouteDescription, constructors []string) []string {
+	var values []string
+	for _, middleware := range route.Middleware {
+		if !matchesConstructor(middleware.Name, constructors) {
+			continue
+		}
+		for _, value := range middleware.Arguments {
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// matchesConstructor compara el nombre del middleware (RequireScopes o
+// auth.RequireScopes) con los constructores configurados, con o sin paquete
+func matchesConstructor(name string, constructors []string) bool {
+	for _, constructor := range constructors {
+		if name == constructor || strings.HasSuffix(name, "."+constructor) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestSecurityFromMiddleware(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if bearerToken(c) == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
+
+func bearerToken(c *gin.Context) string {
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+}
+
+func APIKey(c *gin.Context) {
+	key := c.GetHeader("X-API-Key")
+	if key == "" {
+		key = c.Query("api_key")
+	}
+	if key == "" {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) { c.Next() }
+}
+
+func Health(c *gin.Context)    {}
+func Stats(c *gin.Context)     {}
+func Webhook(c *gin.Context)   {}
+func GetUser(c *gin.Context)   {}
+func ListUsers(c *gin.Context) {}
+
+func main() {
+	r := gin.Default()
+	r.Use(Logger())
+	r.GET("/health", Health)
+	r.POST("/webhook", APIKey, Webhook)
+
+	admin := r.Group("/admin", gin.BasicAuth(gin.Accounts{"admin": "secret"}))
+	admin.GET("/stats", Stats)
+
+	r.Use(AuthRequired())
+	r.GET("/users", ListUsers)
+	r.GET("/users/:id", GetUser)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	schemes := spec.Components.SecuritySchemes
+	expected := map[string]SecurityScheme{
+		"basicAuth":  {Type: "http", Scheme: "basic"},
+		"bearerAuth": {Type: "http", Scheme: "bearer"},
+		"X-API-Key":  {Type: "apiKey", In: "header", Name: "X-API-Key"},
+		"api_key":    {Type: "apiKey", In: "query", Name: "api_key"},
+	}
+	if len(schemes) != len(expected) {
+		t.Errorf("Expected %d security schemes, got %v", len(expected), schemes)
+	}
+	for name, want := range expected {
+		if got, exists := schemes[name]; !exists || got != want {
+			t.Errorf("Expected scheme %s = %+v, got %+v", name, want, got)
+		}
+	}
+
+	// El health check y el logger no exigen autenticación
+	if security := findOperation(spec, "GET /health").Security; security != nil {
+		t.Errorf("Expected no security on /health, got %v", security)
+	}
+
+	// La API key se acepta por header o por query
+	webhook := findOperation(spec, "POST /webhook").Security
+	if len(webhook) != 2 || webhook[0]["X-API-Key"] == nil || webhook[1]["api_key"] == nil {
+		t.Errorf("Expected X-API-Key or api_key on /webhook, got %v", webhook)
+	}
+
+	stats := findOperation(spec, "GET /stats").Security
+	if len(stats) != 1 || stats[0]["basicAuth"] == nil {
+		t.Errorf("Expected basicAuth on /stats, got %v", stats)
+	}
+
+	// Use solo afecta a las rutas registradas después
+	for _, route := range []string{"GET /users", "GET /users/{id}"} {
+		security := findOperation(spec, route).Security
+		if len(security) != 1 || security[0]["bearerAuth"] == nil {
+			t.Errorf("Expected bearerAuth on %s, got %v", route, security)
+		}
+	}
+}
+
+func TestScopesAndRolesFromMiddleware(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ScopeUsersWrite = "users:write"
+
+func AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		_ = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	}
+}
+
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {}
+}
+
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {}
+}
+
+func ListUsers(c *gin.Context)  {}
+func DeleteUser(c *gin.Context) {}
+func Purge(c *gin.Context)      {}
+
+func main() {
+	r := gin.Default()
+	api := r.Group("/api", AuthRequired())
+	api.GET("/users", RequireScopes("users:read"), ListUsers)
+	api.DELETE("/users/:id", RequireScopes(ScopeUsersWrite), RequireRole("admin"), DeleteUser)
+	r.POST("/purge", RequireScopes("admin:purge"), Purge)
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	openapiGenerator := NewOpenAPIGenerator(coordinator)
+	openapiGenerator.Config.ScopeMiddleware = []string{"RequireScopes"}
+	openapiGenerator.Config.RoleMiddleware = []string{"RequireRole"}
+	spec := openapiGenerator.Generate(apiDesc, "Test API", "1.0.0")
+
+	// Los scopes se exigen sobre oauth2 en lugar del token Bearer
+	oauth2, exists := spec.Components.SecuritySchemes["oauth2"]
+	if !exists || oauth2.Flows == nil || oauth2.Flows.AuthorizationCode == nil {
+		t.Fatalf("Expected oauth2 scheme with authorizationCode flow, got %+v", spec.Components.SecuritySchemes)
+	}
+	if _, exists := spec.Components.SecuritySchemes["bearerAuth"]; exists {
+		t.Errorf("Expected bearerAuth to be replaced by oauth2, got %v", spec.Components.SecuritySchemes)
+	}
+	for _, scope := range []string{"users:read", "users:write", "admin:purge"} {
+		if _, exists := oauth2.Flows.AuthorizationCode.Scopes[scope]; !exists {
+			t.Errorf("Expected scope %s in oauth2 flow, got %v", scope, oauth2.Flows.AuthorizationCode.Scopes)
+		}
+	}
+
+	expected := map[string]string{
+		"GET /users":         "users:read",
+		"DELETE /users/{id}": "users:write",
+		"POST /purge":            "admin:purge",
+	}
+	for route, scope := range expected {
+		security := findOperation(spec, route).Security
+		if len(security) != 1 || len(security[0]) != 1 || len(security[0]["oauth2"]) != 1 || security[0]["oauth2"][0] != scope {
+			t.Errorf("Expected oauth2 [%s] on %s, got %v", scope, route, security)
+		}
+	}
+
+	if roles := findOperation(spec, "DELETE /users/{id}").Roles; len(roles) != 1 || roles[0] != "admin" {
+		t.Errorf("Expected x-roles [admin], got %v", roles)
+	}
+	if roles := findOperation(spec, "GET /users").Roles; roles != nil {
+		t.Errorf("Expected no x-roles on GET /users, got %v", roles)
+	}
+}
+
+func TestCookies(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SessionRequired(c *gin.Context) {
+	if _, err := c.Cookie("session_id"); err != nil {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	c.Next()
+}
+
+func Login(c *gin.Context) {
+	c.SetCookie("session_id", "", 3600, "/", "", true, true)
+	c.Status(http.StatusNoContent)
+}
+
+func Preferences(c *gin.Context) {
+	theme, _ := c.Cookie("theme")
+	http.SetCookie(c.Writer, &http.Cookie{Name: "theme", Value: theme, Path: "/"})
+	c.JSON(http.StatusOK, gin.H{"theme": theme})
+}
+
+func main() {
+	r := gin.Default()
+	r.POST("/login", Login)
+	r.GET("/preferences", SessionRequired, Preferences)
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	spec := NewOpenAPIGenerator(coordinator).Generate(apiDesc, "Test API", "1.0.0")
+
+	// La cookie leída en el middleware de autenticación es una API key
+	scheme, exists := spec.Components.SecuritySchemes["session_id"]
+	if !exists || scheme.Type != "apiKey" || scheme.In != "cookie" {
+		t.Fatalf("Expected session_id cookie apiKey scheme, got %+v", spec.Components.SecuritySchemes)
+	}
+	preferences := findOperation(spec, "GET /preferences")
+	if len(preferences.Security) != 1 || preferences.Security[0]["session_id"] == nil {
+		t.Errorf("Expected session_id requirement, got %v", preferences.Security)
+	}
+
+	// Las cookies leídas en el handler son parámetros cookie
+	if len(preferences.Parameters) != 1 || preferences.Parameters[0].Name != "theme" || preferences.Parameters[0].In != "cookie" {
+		t.Errorf("Expected theme cookie parameter, got %+v", preferences.Parameters)
+	}
+	if cookie := preferences.Responses["200"].Headers["Set-Cookie"].Schema; cookie == nil || cookie.Example != "theme=; Path=/" {
+		t.Errorf("Expected Set-Cookie header from http.SetCookie, got %v", preferences.Responses["200"].Headers)
+	}
+
+	login := findOperation(spec, "POST /login").Responses["204"]
+	if cookie := login.Headers["Set-Cookie"].Schema; cookie == nil || cookie.Example != "session_id=; Path=/; Secure; HttpOnly" {
+		t.Errorf("Expected Set-Cookie header from c.SetCookie, got %+v", login.Headers["Set-Cookie"].Schema)
+	}
+
+	// Swagger 2.0 no admite cookies: se omiten el parámetro y el esquema
+	swagger := NewSwagger2Generator(coordinator).Generate(apiDesc, "Test API", "1.0.0")
+	if _, exists := swagger.SecurityDefinitions["session_id"]; exists {
+		t.Errorf("Expected no cookie scheme in Swagger 2.0, got %v", swagger.SecurityDefinitions)
+	}
+	operation := swagger.Paths["/preferences"].Get
+	if len(operation.Parameters) != 0 || len(operation.Security) != 0 {
+		t.Errorf("Expected no cookie parameters or requirements in Swagger 2.0, got %+v %v", operation.Parameters, operation.Security)
+	}
+}
@@ -0,0 +1,523 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+const (
+	definitionsPrefix      = "#/definitions/"
+	swaggerResponsesPrefix = "#/responses/"
+)
+
+// formMediaTypes son los content types que Swagger 2.0 modela como parámetros formData
+var formMediaTypes = []string{"multipart/form-data", "application/x-www-form-urlencoded"}
+
+// Swagger2Generator genera especificaciones Swagger 2.0 para consumidores antiguos.
+// Construye la misma especificación que OpenAPIGenerator y la convierte.
+type Swagger2Generator struct {
+	Config  Config
+	openapi *OpenAPIGenerator
+}
+
+// NewSwagger2Generator crea un nuevo generador Swagger 2.0
+func NewSwagger2Generator(coordinator *internal.EnhancedCoordinator) *Swagger2Generator {
+	return &Swagger2Generator{
+		Config:  DefaultConfig(),
+		openapi: NewOpenAPIGenerator(coordinator),
+	}
+}
+
+type SwaggerSpec struct {
+	Swagger     string                     `json:"swagger"`
+	Info        Info                       `json:"info"`
+	BasePath    string                     `json:"basePath,omitempty"`
+	Consumes    []string                   `json:"consumes,omitempty"`
+	Produces    []string                   `json:"produces,omitempty"`
+	Paths       map[string]SwaggerPathItem `json:"paths"`
+	Definitions map[string]Schema          `json:"definitions,omitempty"`
+	Responses   map[string]SwaggerResponse `json:"responses,omitempty"`
+
+	SecurityDefinitions map[string]SwaggerSecurityScheme `json:"securityDefinitions,omitempty"`
+}
+
+// SwaggerSecurityScheme es un esquema de securityDefinitions: Swagger 2.0 solo
+// admite basic, apiKey y oauth2, así que bearer se documenta como API key en Authorization
+type SwaggerSecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Campos de oauth2: un único flujo (implicit, accessCode o application)
+	Flow             string            `json:"flow,omitempty"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"`
+}
+
+type SwaggerPathItem struct {
+	Get    *SwaggerOperation `json:"get,omitempty"`
+	Put    *SwaggerOperation `json:"put,omitempty"`
+	Post   *SwaggerOperation `json:"post,omitempty"`
+	Delete *SwaggerOperation `json:"delete,omitempty"`
+	Patch  *SwaggerOperation `json:"patch,omitempty"`
+}
+
+type SwaggerOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	OperationID string                     `json:"operationId,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Consumes    []string                   `json:"consumes,omitempty"`
+	Produces    []string                   `json:"produces,omitempty"`
+	Parameters  []SwaggerParameter         `json:"parameters,omitempty"`
+	Responses   map[string]SwaggerResponse `json:"responses"`
+	Security    []SecurityRequirement      `json:"security,omitempty"`
+	Roles       []string                   `json:"x-roles,omitempty"`
+	WebSocket   *WebSocketExtension        `json:"x-websocket,omitempty"`
+}
+
+type SwaggerParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"` // path, query, header, body, formData
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required,omitempty"`
+	Schema      *Schema       `json:"schema,omitempty"` // Solo in: body
+	Type        string        `json:"type,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Items       *Schema       `json:"items,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+
+	Minimum          *float64    `json:"minimum,omitempty"`
+	Maximum          *float64    `json:"maximum,omitempty"`
+	ExclusiveMinimum interface{} `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum interface{} `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int        `json:"minLength,omitempty"`
+	MaxLength        *int        `json:"maxLength,omitempty"`
+	MinItems         *int        `json:"minItems,omitempty"`
+	MaxItems         *int        `json:"maxItems,omitempty"`
+	Pattern          string      `json:"pattern,omitempty"`
+}
+
+type SwaggerResponse struct {
+	Ref         string                   `json:"$ref,omitempty"`
+	Description string                   `json:"description,omitempty"` // Obligatoria salvo en referencias
+	Schema      *Schema                  `json:"schema,omitempty"`
+	Headers     map[string]SwaggerHeader `json:"headers,omitempty"`
+	Examples    map[string]interface{}   `json:"examples,omitempty"`
+}
+
+// SwaggerHeader es un header de respuesta; como los parámetros, declara el tipo directamente
+type SwaggerHeader struct {
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+	Format      string `json:"format,omitempty"`
+}
+
+// Generate construye la especificación Swagger 2.0
+func (g *Swagger2Generator) Generate(apiDesc *internal.APIDescription, title, version string) *SwaggerSpec {
+	g.openapi.Config = g.Config
+	g.openapi.Config.OpenAPIVersion = OpenAPI30
+	source := g.openapi.Generate(apiDesc, title, version)
+
+	spec := &SwaggerSpec{
+		Swagger:     "2.0",
+		Info:        source.Info,
+		Paths:       make(map[string]SwaggerPathItem),
+		Definitions: make(map[string]Schema),
+	}
+
+	// Las referencias apuntan a definitions y se eliminan las construcciones de 3.x
+	walkSpecSchemas(source, convertSchemaTo20)
+
+	for name, schema := range source.Components.Schemas {
+		spec.Definitions[name] = schema
+	}
+
+	consumes := make(map[string]bool)
+	produces := make(map[string]bool)
+	if len(source.Components.Responses) > 0 {
+		spec.Responses = make(map[string]SwaggerResponse)
+		for name, response := range source.Components.Responses {
+			spec.Responses[name] = convertResponse(response, make(map[string]bool), produces)
+		}
+	}
+
+	for name, scheme := range source.Components.SecuritySchemes {
+		if scheme.In == "cookie" {
+			continue // Swagger 2.0 no admite API keys en cookies
+		}
+		if spec.SecurityDefinitions == nil {
+			spec.SecurityDefinitions = make(map[string]SwaggerSecurityScheme)
+		}
+		spec.SecurityDefinitions[name] = convertSecurityScheme(scheme)
+	}
+
+	basePath := commonBasePath(source.Paths)
+	if basePath != "/" {
+		spec.BasePath = basePath
+	}
+
+	for path, pathItem := range source.Paths {
+		swaggerPath := strings.TrimPrefix(path, strings.TrimSuffix(basePath, "/"))
+		if swaggerPath == "" {
+			swaggerPath = "/"
+		}
+
+		spec.Paths[swaggerPath] = SwaggerPathItem{
+			Get:    g.convertOperation(spec, source.Components, pathItem.Get, consumes, produces),
+			Put:    g.convertOperation(spec, source.Components, pathItem.Put, consumes, produces),
+			Post:   g.convertOperation(spec, source.Components, pathItem.Post, consumes, produces),
+			Delete: g.convertOperation(spec, source.Components, pathItem.Delete, consumes, produces),
+			Patch:  g.convertOperation(spec, source.Components, pathItem.Patch, consumes, produces),
+		}
+	}
+
+	spec.Consumes = sortedKeys(consumes)
+	spec.Produces = sortedKeys(produces)
+
+	// Las operaciones solo declaran consumes/produces cuando difieren de los globales
+	for _, pathItem := range spec.Paths {
+		for _, operation := range []*SwaggerOperation{pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete, pathItem.Patch} {
+			if operation == nil {
+				continue
+			}
+			if equalStrings(operation.Consumes, spec.Consumes) {
+				operation.Consumes = nil
+			}
+			if equalStrings(operation.Produces, spec.Produces) {
+				operation.Produces = nil
+			}
+		}
+	}
+
+	return spec
+}
+
+// convertOperation traduce una operación OpenAPI 3.0 a Swagger 2.0
+func (g *Swagger2Generator) convertOperation(spec *SwaggerSpec, components *Components, operation *Operation, consumes, produces map[string]bool) *SwaggerOperation {
+	if operation == nil {
+		return nil
+	}
+
+	converted := &SwaggerOperation{
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		OperationID: operation.OperationID,
+		Deprecated:  operation.Deprecated,
+		Tags:        operation.Tags,
+		Responses:   make(map[string]SwaggerResponse),
+		Security:    swaggerSecurity(operation.Security, components.SecuritySchemes),
+		Roles:       operation.Roles,
+		WebSocket:   operation.WebSocket,
+	}
+
+	for _, param := range operation.Parameters {
+		if param.In == "cookie" {
+			continue // Swagger 2.0 no admite parámetros cookie
+		}
+		converted.Parameters = append(converted.Parameters, simpleParameter(param.Name, param.In, param.Description, param.Required, param.Schema))
+	}
+
+	if operation.RequestBody != nil {
+		mediaTypes := sortedKeys(operation.RequestBody.Content)
+		converted.Consumes = mediaTypes
+		for _, mediaType := range mediaTypes {
+			consumes[mediaType] = true
+		}
+		converted.Parameters = append(converted.Parameters, g.bodyParameters(spec, operation.RequestBody)...)
+	}
+
+	operationProduces := make(map[string]bool)
+	for code, response := range operation.Responses {
+		// Las respuestas globales producen los content types que declaran
+		if global, exists := components.Responses[strings.TrimPrefix(response.Ref, responsesPrefix)]; exists && response.Ref != "" {
+			for mediaType := range global.Content {
+				operationProduces[mediaType] = true
+			}
+		}
+		converted.Responses[code] = convertResponse(response, operationProduces, produces)
+	}
+	converted.Produces = sortedKeys(operationProduces)
+
+	return converted
+}
+
+// swaggerSecurity quita las alternativas que exigen una API key en cookie, que no
+// existe en Swagger 2.0
+func swaggerSecurity(requirements []SecurityRequirement, schemes map[string]SecurityScheme) []SecurityRequirement {
+	var converted []SecurityRequirement
+	for _, requirement := range requirements {
+		supported := true
+		for name := range requirement {
+			if schemes[name].In == "cookie" {
+				supported = false
+			}
+		}
+		if supported {
+			converted = append(converted, requirement)
+		}
+	}
+	return converted
+}
+
+// convertSecurityScheme traduce un esquema de OpenAPI 3.0 a Swagger 2.0
+func convertSecurityScheme(scheme SecurityScheme) SwaggerSecurityScheme {
+	switch {
+	case scheme.Type == "http" && scheme.Scheme == "basic":
+		return SwaggerSecurityScheme{Type: "basic", Description: scheme.Description}
+	case scheme.Type == "http":
+		return SwaggerSecurityScheme{
+			Type:        "apiKey",
+			In:          "header",
+			Name:        "Authorization",
+			Description: "Bearer token: \"Bearer {token}\"",
+		}
+	case scheme.Type == "oauth2" && scheme.Flows != nil:
+		flow := scheme.Flows.flow()
+		converted := SwaggerSecurityScheme{
+			Type:             "oauth2",
+			Description:      scheme.Description,
+			AuthorizationURL: flow.AuthorizationURL,
+			TokenURL:         flow.TokenURL,
+			Scopes:           flow.Scopes,
+		}
+		switch {
+		case scheme.Flows.AuthorizationCode != nil:
+			converted.Flow = "accessCode"
+		case scheme.Flows.Implicit != nil:
+			converted.Flow = "implicit"
+		default:
+			converted.Flow = "application"
+		}
+		return converted
+	default:
+		return SwaggerSecurityScheme{Type: scheme.Type, In: scheme.In, Name: scheme.Name, Description: scheme.Description}
+	}
+}
+
+// convertResponse traduce una respuesta OpenAPI 3.0; Swagger 2.0 admite un único schema
+// por respuesta y ejemplos por content type
+func convertResponse(response Response, operationProduces, produces map[string]bool) SwaggerResponse {
+	if response.Ref != "" {
+		return SwaggerResponse{Ref: swaggerResponsesPrefix + strings.TrimPrefix(response.Ref, responsesPrefix)}
+	}
+
+	converted := SwaggerResponse{Description: response.Description}
+	for _, mediaType := range sortedKeys(response.Content) {
+		media := response.Content[mediaType]
+		operationProduces[mediaType] = true
+		produces[mediaType] = true
+
+		if converted.Schema == nil {
+			converted.Schema = media.Schema
+			// Las descargas se declaran como type: file
+			if media.Schema != nil && media.Schema.Type == "string" && media.Schema.Format == "binary" {
+				converted.Schema = &Schema{Type: "file", Description: media.Schema.Description}
+			}
+		}
+		if media.Example != nil {
+			if converted.Examples == nil {
+				converted.Examples = make(map[string]interface{})
+			}
+			converted.Examples[mediaType] = media.Example
+		}
+	}
+
+	for name, header := range response.Headers {
+		if converted.Headers == nil {
+			converted.Headers = make(map[string]SwaggerHeader)
+		}
+		converted.Headers[name] = SwaggerHeader{
+			Description: header.Description,
+			Type:        header.Schema.Type,
+			Format:      header.Schema.Format,
+		}
+	}
+	return converted
+}
+
+// bodyParameters convierte el request body en un parámetro in: body o en parámetros formData
+func (g *Swagger2Generator) bodyParameters(spec *SwaggerSpec, body *RequestBody) []SwaggerParameter {
+	for _, mediaType := range formMediaTypes {
+		media, exists := body.Content[mediaType]
+		if !exists || media.Schema == nil {
+			continue
+		}
+
+		schema := media.Schema
+		if schema.Ref != "" {
+			if definition, exists := spec.Definitions[strings.TrimPrefix(schema.Ref, definitionsPrefix)]; exists {
+				schema = &definition
+			}
+		}
+
+		var params []SwaggerParameter
+		required := make(map[string]bool)
+		for _, name := range schema.Required {
+			required[name] = true
+		}
+		for _, name := range schema.PropertyNames() {
+			property := schema.Properties[name]
+			params = append(params, simpleParameter(name, "formData", property.Description, required[name], &property))
+		}
+		return params
+	}
+
+	for _, mediaType := range sortedKeys(body.Content) {
+		return []SwaggerParameter{{
+			Name:        "body",
+			In:          "body",
+			Description: body.Description,
+			Required:    body.Required,
+			Schema:      body.Content[mediaType].Schema,
+		}}
+	}
+	return nil
+}
+
+// simpleParameter crea un parámetro no-body; en Swagger 2.0 el tipo va en el propio parámetro
+func simpleParameter(name, in, description string, required bool, schema *Schema) SwaggerParameter {
+	param := SwaggerParameter{
+		Name:        name,
+		In:          in,
+		Description: description,
+		Required:    required,
+	}
+
+	if schema != nil {
+		param.Type = schema.Type
+		param.Format = schema.Format
+		param.Items = schema.Items
+		param.Enum = schema.Enum
+		param.Default = schema.Default
+		param.Minimum, param.Maximum = schema.Minimum, schema.Maximum
+		param.ExclusiveMinimum, param.ExclusiveMaximum = schema.ExclusiveMinimum, schema.ExclusiveMaximum
+		param.MinLength, param.MaxLength = schema.MinLength, schema.MaxLength
+		param.MinItems, param.MaxItems = schema.MinItems, schema.MaxItems
+		param.Pattern = schema.Pattern
+
+		// Los archivos se declaran como type: file
+		if schema.Type == "string" && schema.Format == "binary" {
+			param.Type = "file"
+			param.Format = ""
+		}
+	}
+
+	if param.Type == "" {
+		param.Type = "string"
+	}
+	return param
+}
+
+// convertSchemaTo20 adapta un schema a Swagger 2.0
+func convertSchemaTo20(schema *Schema) {
+	if strings.HasPrefix(schema.Ref, componentsPrefix) {
+		schema.Ref = definitionsPrefix + strings.TrimPrefix(schema.Ref, componentsPrefix)
+	}
+
+	// oneOf y discriminator con propertyName no existen en 2.0
+	if len(schema.OneOf) > 0 {
+		schema.OneOf = nil
+		schema.Type = "object"
+	}
+	schema.Discriminator = nil
+	schema.Deprecated = false
+}
+
+// commonBasePath calcula el prefijo estático común a todas las rutas, dejando
+// al menos un segmento estático en cada ruta (/api/v1/users → /api/v1)
+func commonBasePath(paths map[string]PathItem) string {
+	var prefix []string
+	first := true
+	for path := range paths {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		if first {
+			prefix = segments
+			first = false
+			continue
+		}
+
+		common := 0
+		for common < len(prefix) && common < len(segments) && prefix[common] == segments[common] {
+			common++
+		}
+		prefix = prefix[:common]
+	}
+
+	for len(prefix) > 0 {
+		if isValidBasePath(paths, prefix) {
+			return "/" + strings.Join(prefix, "/")
+		}
+		prefix = prefix[:len(prefix)-1]
+	}
+	return "/"
+}
+
+// isValidBasePath verifica que el prefijo sea estático y que cada ruta conserve un segmento estático después de él
+func isValidBasePath(paths map[string]PathItem, prefix []string) bool {
+	for _, segment := range prefix {
+		if strings.HasPrefix(segment, "{") {
+			return false
+		}
+	}
+
+	for path := range paths {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		if len(segments) <= len(prefix) || strings.HasPrefix(segments[len(prefix)], "{") {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Diagnostics devuelve los avisos de la última generación
+func (g *Swagger2Generator) Diagnostics() []string {
+	return g.openapi.Diagnostics()
+}
+
+// Renames devuelve los componentes renombrados en la última generación
+func (g *Swagger2Generator) Renames() map[string]string {
+	return g.openapi.Renames()
+}
+
+func (g *Swagger2Generator) GenerateJSON(apiDesc *internal.APIDescription, title, version string) ([]byte, error) {
+	spec := g.Generate(apiDesc, title, version)
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+func (g *Swagger2Generator) SaveToFile(apiDesc *internal.APIDescription, filename, title, version string) error {
+	data, err := g.GenerateJSON(apiDesc, title, version)
+	if err != nil {
+		return err
+	}
+
+	// .yaml/.yml se escriben en YAML; el resto en JSON
+	data, err = EncodeSpec(data, FormatFromFilename(filename))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
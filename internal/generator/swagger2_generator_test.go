@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Larry-Baltodano/go-auto-swagger/internal"
+)
+
+func TestSwagger2Output(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type User struct {
+	ID      int      ` + "`json:\"id\"`" + `
+	Manager *User    ` + "`json:\"manager\"`" + `
+}
+
+type CreateUserRequest struct {
+	Name string ` + "`json:\"name\" binding:\"required\"`" + `
+}
+
+func GetUser(c *gin.Context) {
+	c.JSON(200, User{})
+}
+
+func CreateUser(c *gin.Context) {
+	var req CreateUserRequest
+	c.ShouldBindJSON(&req)
+	c.JSON(201, User{})
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/api/v1/users/:id", GetUser)
+	r.POST("/api/v1/users", CreateUser)
+}
+`
+
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	coordinator := internal.NewEnhancedCoordinator()
+	apiDesc, err := coordinator.AnalyzeAPI(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeAPI failed: %v", err)
+	}
+
+	spec := NewSwagger2Generator(coordinator).Generate(apiDesc, "Test API", "1.0.0")
+
+	if spec.Swagger != "2.0" {
+		t.Errorf("Expected swagger 2.0, got %s", spec.Swagger)
+	}
+	if spec.BasePath != "/api/v1" {
+		t.Errorf("Expected basePath /api/v1, got %q", spec.BasePath)
+	}
+	if len(spec.Consumes) != 1 || spec.Consumes[0] != "application/json" {
+		t.Errorf("Expected global consumes application/json, got %v", spec.Consumes)
+	}
+	if len(spec.Produces) != 1 || spec.Produces[0] != "application/json" {
+		t.Errorf("Expected global produces application/json, got %v", spec.Produces)
+	}
+
+	user, exists := spec.Definitions["User"]
+	if !exists {
+		t.Fatalf("Expected User definition, got %v", spec.Definitions)
+	}
+	if manager := user.Properties["manager"]; manager.Ref != "#/definitions/User" {
+		t.Errorf("Expected manager to reference #/definitions/User, got %+v", manager)
+	}
+
+	post := spec.Paths["/users"].Post
+	if post == nil {
+		t.Fatalf("Expected POST /users relative to basePath, got %v", spec.Paths)
+	}
+	if post.Consumes != nil {
+		t.Errorf("Expected operation to inherit global consumes, got %v", post.Consumes)
+	}
+
+	var body *SwaggerParameter
+	for i := range post.Parameters {
+		if post.Parameters[i].In == "body" {
+			body = &post.Parameters[i]
+		}
+	}
+	if body == nil || body.Schema == nil || body.Schema.Ref != "#/definitions/CreateUserRequest" {
+		t.Fatalf("Expected body parameter referencing CreateUserRequest, got %+v", post.Parameters)
+	}
+
+	get := spec.Paths["/users/{id}"].Get
+	if get == nil {
+		t.Fatalf("Expected GET /users/{id}, got %v", spec.Paths)
+	}
+	if response := get.Responses["200"]; response.Schema == nil || response.Schema.Ref != "#/definitions/User" {
+		t.Errorf("Expected 200 response schema referencing User, got %+v", response)
+	}
+}
+
+func TestSimpleParameterTypes(t *testing.T) {
+	param := simpleParameter("avatar", "formData", "", true, &Schema{Type: "string", Format: "binary"})
+	if param.Type != "file" || param.Format != "" {
+		t.Errorf("Expected file parameter, got %+v", param)
+	}
+
+	param = simpleParameter("limit", "query", "", false, &Schema{Type: "integer", Format: "int32"})
+	if param.Type != "integer" || param.Format != "int32" {
+		t.Errorf("Expected integer parameter, got %+v", param)
+	}
+}
+
+func TestCommonBasePath(t *testing.T) {
+	tests := []struct {
+		paths    []string
+		expected string
+	}{
+		{[]string{"/api/v1/users", "/api/v1/orders/{id}"}, "/api/v1"},
+		{[]string{"/api/v1/users", "/api/v2/users"}, "/api"},
+		{[]string{"/users/{id}", "/users"}, "/"},
+		{[]string{"/api/users"}, "/api"},
+		{[]string{"/{id}"}, "/"},
+	}
+
+	for _, test := range tests {
+		paths := make(map[string]PathItem)
+		for _, path := range test.paths {
+			paths[path] = PathItem{}
+		}
+		if basePath := commonBasePath(paths); basePath != test.expected {
+			t.Errorf("Expected basePath %s for %v, got %s", test.expected, test.paths, basePath)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package generator
+
+// walkSpecSchemas visita todos los schemas de la especificación, incluidos los anidados
+func walkSpecSchemas(spec *OpenAPISpec, visit func(*Schema)) {
+	for _, pathItem := range spec.Paths {
+		for _, operation := range pathItem.operations() {
+			walkOperationSchemas(operation, visit)
+		}
+	}
+
+	if spec.Components != nil {
+		walkSchemaMap(spec.Components.Schemas, visit)
+		for _, response := range spec.Components.Responses {
+			walkResponseSchemas(response, visit)
+		}
+	}
+}
+
+// walkOperationSchemas visita los schemas de parámetros, request body, respuestas y
+// mensajes WebSocket
+func walkOperationSchemas(operation *Operation, visit func(*Schema)) {
+	for i := range operation.Parameters {
+		walkSchema(operation.Parameters[i].Schema, visit)
+	}
+
+	if operation.RequestBody != nil {
+		walkContentSchemas(operation.RequestBody.Content, visit)
+	}
+
+	for _, response := range operation.Responses {
+		walkResponseSchemas(response, visit)
+	}
+
+	if operation.WebSocket != nil {
+		walkSchema(operation.WebSocket.Receives, visit)
+		walkSchema(operation.WebSocket.Sends, visit)
+	}
+}
+
+// walkResponseSchemas visita los schemas de los headers y del contenido de una respuesta
+func walkResponseSchemas(response Response, visit func(*Schema)) {
+	for _, header := range response.Headers {
+		walkSchema(header.Schema, visit)
+	}
+	walkContentSchemas(response.Content, visit)
+}
+
+func walkContentSchemas(content map[string]MediaType, visit func(*Schema)) {
+	for _, mediaType := range content {
+		walkSchema(mediaType.Schema, visit)
+	}
+}
+
+func walkSchemaMap(schemas map[string]Schema, visit func(*Schema)) {
+	for name, schema := range schemas {
+		walkSchema(&schema, visit)
+		schemas[name] = schema
+	}
+}
+
+// walkSchema visita un schema y luego sus hijos
+func walkSchema(schema *Schema, visit func(*Schema)) {
+	if schema == nil {
+		return
+	}
+
+	visit(schema)
+	walkSchemaMap(schema.Properties, visit)
+	walkSchema(schema.AdditionalProperties, visit)
+	walkSchema(schema.Items, visit)
+	for i := range schema.AllOf {
+		walkSchema(&schema.AllOf[i], visit)
+	}
+	for i := range schema.OneOf {
+		walkSchema(&schema.OneOf[i], visit)
+	}
+}
+
+// operations devuelve las operaciones definidas en el path item
+func (p *PathItem) operations() []*Operation {
+	var operations []*Operation
+	for _, operation := range []*Operation{p.Get, p.Put, p.Post, p.Delete, p.Patch} {
+		if operation != nil {
+			operations = append(operations, operation)
+		}
+	}
+	return operations
+}
@@ -0,0 +1,40 @@
+package generator
+
+import "github.com/Larry-Baltodano/go-auto-swagger/internal/handler"
+
+// WebSocketExtension es la extensión x-websocket de las operaciones que abren un
+// WebSocket: la librería usada y los mensajes JSON que el servidor recibe y envía
+type WebSocketExtension struct {
+	Library  string  `json:"library,omitempty"`
+	Receives *Schema `json:"receives,omitempty"`
+	Sends    *Schema `json:"sends,omitempty"`
+}
+
+// webSocketExtension documenta la conexión WebSocket del handler (nil si no abre ninguna)
+func (g *OpenAPIGenerator) webSocketExtension(handlerInfo *handler.HandlerInfo) *WebSocketExtension {
+	if handlerInfo == nil || handlerInfo.WebSocket == nil {
+		return nil
+	}
+
+	return &WebSocketExtension{
+		Library:  handlerInfo.WebSocket.Library,
+		Receives: g.messageSchema(handlerInfo.WebSocket.Receives),
+		Sends:    g.messageSchema(handlerInfo.WebSocket.Sends),
+	}
+}
+
+// messageSchema construye el schema de los mensajes; varios tipos se combinan con oneOf
+func (g *OpenAPIGenerator) messageSchema(messages []handler.MessageInfo) *Schema {
+	if len(messages) == 0 {
+		return nil
+	}
+	if len(messages) == 1 {
+		return g.schemas.SchemaFor(messages[0].GoType)
+	}
+
+	schema := &Schema{}
+	for _, message := range messages {
+		schema.OneOf = append(schema.OneOf, *g.schemas.SchemaFor(message.GoType))
+	}
+	return schema
+}
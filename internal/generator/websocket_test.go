@@ -0,0 +1,119 @@
+package generator
+
+import "testing"
+
+func TestWebSocketUpgrade(t *testing.T) {
+	testCode := `
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"nhooyr.io/websocket/wsjson"
+	nhooyr "nhooyr.io/websocket"
+	coder "github.com/coder/websocket"
+	cws "github.com/coder/websocket/wsjson"
+)
+
+type ChatMessage struct {
+	Text string ` + "`json:\"text\"`" + `
+}
+
+type Broadcast struct {
+	From string ` + "`json:\"from\"`" + `
+	Text string ` + "`json:\"text\"`" + `
+}
+
+type Presence struct {
+	Online int ` + "`json:\"online\"`" + `
+}
+
+var upgrader = websocket.Upgrader{}
+
+func Chat(c *gin.Context) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		var message ChatMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+		conn.WriteJSON(Broadcast{Text: message.Text})
+		conn.WriteJSON(Presence{})
+	}
+}
+
+func Feed(c *gin.Context) {
+	conn, err := nhooyr.Accept(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	wsjson.Write(c, conn, Presence{})
+}
+
+func Echo(c *gin.Context) {
+	conn, err := coder.Accept(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	var message ChatMessage
+	cws.Read(c, conn, &message)
+}
+
+func main() {
+	r := gin.Default()
+	r.GET("/chat", Chat)
+	r.GET("/feed", Feed)
+	r.GET("/echo", Echo)
+}
+`
+
+	spec := generateFromSource(t, testCode)
+
+	chat := findOperation(spec, "GET /chat")
+	switching, exists := chat.Responses["101"]
+	if !exists || switching.Content != nil {
+		t.Fatalf("Expected 101 response without content, got %+v", chat.Responses)
+	}
+	if _, exists := switching.Headers["Upgrade"]; !exists {
+		t.Errorf("Expected Upgrade header on 101, got %v", switching.Headers)
+	}
+	if _, exists := chat.Responses["200"]; exists {
+		t.Errorf("Expected no default 200 response, got %v", chat.Responses)
+	}
+
+	if chat.WebSocket == nil || chat.WebSocket.Library != "github.com/gorilla/websocket" {
+		t.Fatalf("Expected x-websocket for gorilla, got %+v", chat.WebSocket)
+	}
+	if receives := chat.WebSocket.Receives; receives == nil || receives.Ref != "#/components/schemas/ChatMessage" {
+		t.Errorf("Expected ChatMessage as received message, got %+v", receives)
+	}
+	if sends := chat.WebSocket.Sends; sends == nil || len(sends.OneOf) != 2 {
+		t.Errorf("Expected Broadcast and Presence as sent messages, got %+v", sends)
+	}
+
+	feed := findOperation(spec, "GET /feed")
+	if feed.WebSocket == nil || feed.WebSocket.Library != "nhooyr.io/websocket" {
+		t.Fatalf("Expected x-websocket for nhooyr, got %+v", feed.WebSocket)
+	}
+	if sends := feed.WebSocket.Sends; sends == nil || sends.Ref != "#/components/schemas/Presence" {
+		t.Errorf("Expected Presence as sent message, got %+v", sends)
+	}
+	if feed.WebSocket.Receives != nil {
+		t.Errorf("Expected no received messages, got %+v", feed.WebSocket.Receives)
+	}
+
+	// wsjson se reconoce por la ruta de importación, aunque se importe con otro nombre
+	echo := findOperation(spec, "GET /echo")
+	if echo.WebSocket == nil || echo.WebSocket.Library != "github.com/coder/websocket" {
+		t.Fatalf("Expected x-websocket for coder/websocket, got %+v", echo.WebSocket)
+	}
+	if receives := echo.WebSocket.Receives; receives == nil || receives.Ref != "#/components/schemas/ChatMessage" {
+		t.Errorf("Expected ChatMessage as received message through aliased wsjson, got %+v", receives)
+	}
+}
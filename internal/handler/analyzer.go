@@ -1,29 +1,79 @@
 package handler
 
 import (
-	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"strings"
 )
 
 type ParamInfo struct {
-	Name     string
-	Type     string
-	Location string
-	Required bool
-	JSONName string
+	Name      string
+	Type      string
+	Location  string
+	Required  bool
+	JSONName  string
 	SubParams []ParamInfo
+	GoType    types.Type // Tipo resuelto por go/types, nil si no se pudo resolver
+
+	// ContentTypes son los media types que acepta un parámetro body; nil es application/json
+	ContentTypes []string
+
+	// BindingTag es el tag con el que gin enlaza los campos del struct (form, uri, header);
+	// cada campo se documenta como parámetro o propiedad del formulario
+	BindingTag string
+}
+
+// ResponseInfo describe una respuesta escrita por el handler (c.JSON y similares);
+// GoType es nil cuando la respuesta no tiene cuerpo (c.AbortWithStatus)
+type ResponseInfo struct {
+	StatusCode int
+	Type       string
+	GoType     types.Type
+
+	// ContentType es el media type escrito; vacío es application/json
+	ContentType string
+	// Binary indica un cuerpo binario sin estructura (c.File, c.Data, c.ProtoBuf)
+	Binary bool
+	// Headers son los headers de respuesta que fija el writer (Content-Disposition)
+	Headers []HeaderInfo
+	// Events son los eventos enviados con c.SSEvent en una respuesta text/event-stream
+	Events []EventInfo
+	// Description detalla la respuesta, como el destino de una redirección
+	Description string
+}
+
+// EventInfo describe un evento server-sent: el nombre constante (vacío si no se conoce)
+// y el tipo del payload
+type EventInfo struct {
+	Name   string
+	Type   string
+	GoType types.Type
+}
+
+// HeaderInfo describe un header de respuesta; Value es el valor constante si se conoce
+type HeaderInfo struct {
+	Name  string
+	Value string
+}
+
+// HasBody indica si la respuesta tiene cuerpo
+func (r ResponseInfo) HasBody() bool {
+	return r.GoType != nil || r.Binary
 }
 
 type HandlerInfo struct {
 	Name        string
+	Receiver    string
+	Doc         string
 	Package     string
 	File        string
 	Params      []ParamInfo
 	ReturnType  string
 	ErrorReturn bool
+	Responses   []ResponseInfo
+	WebSocket   *WebSocketInfo // Conexión WebSocket abierta por el handler (nil si no hay)
 }
 
 type HandlerAnalyzer struct {
@@ -80,10 +130,12 @@ func (a *HandlerAnalyzer) AnalyzeMethod(packageName, filePath, structName, metho
 
 func (a *HandlerAnalyzer) analyzeFunctionDeclaration(packageName, filePath string, funcDecl *ast.FuncDecl) *HandlerInfo {
 	info := &HandlerInfo{
-		Name:    funcDecl.Name.Name,
-		Package: packageName,
-		File:    filePath,
-		Params:  []ParamInfo{},
+		Name:     funcDecl.Name.Name,
+		Receiver: receiverName(funcDecl),
+		Doc:      funcDecl.Doc.Text(),
+		Package:  packageName,
+		File:     filePath,
+		Params:   []ParamInfo{},
 	}
 
 	if funcDecl.Type.Params != nil {
@@ -158,6 +210,14 @@ func (a *HandlerAnalyzer) getTypeName(expr ast.Expr) string {
 		return "[]" + a.getTypeName(t.Elt)
 	case *ast.StructType:
 		return "struct{}"
+	case *ast.IndexExpr:
+		return a.getTypeName(t.X) + "[" + a.getTypeName(t.Index) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, 0, len(t.Indices))
+		for _, index := range t.Indices {
+			args = append(args, a.getTypeName(index))
+		}
+		return a.getTypeName(t.X) + "[" + strings.Join(args, ", ") + "]"
 	default:
 		return "unknown"
 	}
@@ -197,9 +257,7 @@ func (a *HandlerAnalyzer) analyzeStructFields(expr ast.Expr, paramInfo *ParamInf
 		if t.Fields != nil {
 			for _, field := range t.Fields.List {
 				if field.Tag != nil {
-					tag := field.Tag.Value			
 					paramInfo.Required = true
-					fmt.Println(tag) // -> eliminar luego									
 				}
 			}
 		}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+)
+
+// maxHelperDepth limita cuántos niveles de helpers (respondError(c, ...)) se siguen
+const maxHelperDepth = 3
+
+// bodyScope es el contexto de análisis de un cuerpo de función: el handler o un helper
+// al que se le pasó el *gin.Context. Los parámetros del helper se resuelven a los
+// argumentos de la llamada para obtener códigos y tipos concretos.
+type bodyScope struct {
+	pkg          *PackageInfo
+	contextNames map[string]bool
+	args         map[types.Object]callArgument
+	depth        int
+	headerWrites *[]headerWrite // Compartido con los helpers del handler
+}
+
+// callArgument es la expresión pasada a un parámetro, en el ámbito de quien llama
+type callArgument struct {
+	scope *bodyScope
+	expr  ast.Expr
+}
+
+// analyzeHandlerBody recorre el cuerpo del handler buscando llamadas sobre *gin.Context
+func (a *EnhancedHandlerAnalyzer) analyzeHandlerBody(info *HandlerInfo, pkg *PackageInfo, funcDecl *ast.FuncDecl) {
+	contextNames := a.contextParamNames(funcDecl)
+	if len(contextNames) == 0 {
+		return
+	}
+
+	var headerWrites []headerWrite
+	a.analyzeBody(info, &bodyScope{pkg: pkg, contextNames: contextNames, headerWrites: &headerWrites}, funcDecl)
+	applyHeaderWrites(info, headerWrites)
+}
+
+func (a *EnhancedHandlerAnalyzer) analyzeBody(info *HandlerInfo, scope *bodyScope, funcDecl *ast.FuncDecl) {
+	if funcDecl.Body == nil {
+		return
+	}
+
+	forms := make(map[types.Object]bool)
+	conns := make(map[types.Object]bool)
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			a.trackMultipartForms(forms, scope, x)
+			a.trackWebSocketConns(conns, scope, x)
+		case *ast.IndexExpr:
+			a.recordMultipartField(info, forms, scope, x)
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		method, ok := contextMethod(call, scope.contextNames)
+		if !ok {
+			switch {
+			case isWriterFlush(call, scope.contextNames):
+				a.recordEventStream(info, nil)
+			case isWebSocketUpgrade(call, scope.contextNames):
+				a.recordWebSocket(info, scope, call)
+			default:
+				a.recordHeaderWrite(info, scope, call) // c.Writer.Header().Set(...), http.SetCookie(c.Writer, ...)
+				a.recordCookieRead(info, scope, call)  // c.Request.Cookie(...)
+				a.recordWebSocketMessage(info, conns, scope, call)
+				a.analyzeHelperCall(info, scope, call)
+			}
+			return true
+		}
+
+		switch method {
+		case "ShouldBindJSON", "BindJSON":
+			a.recordBodyBinding(info, scope, call, nil)
+		case "ShouldBind", "Bind", "ShouldBindWith", "BindWith", "MustBindWith", "ShouldBindBodyWith":
+			a.recordFormBinding(info, scope, call)
+		case "ShouldBindQuery", "BindQuery":
+			a.recordParamBinding(info, scope, call, "query", "form")
+		case "ShouldBindHeader", "BindHeader":
+			a.recordParamBinding(info, scope, call, "header", "header")
+		case "ShouldBindUri", "BindUri":
+			a.recordParamBinding(info, scope, call, "path", "uri")
+		case "FormFile":
+			a.recordFormField(info, scope, call, "file", true)
+		case "PostForm", "DefaultPostForm", "GetPostForm":
+			a.recordFormField(info, scope, call, "string", false)
+		case "PostFormArray", "GetPostFormArray":
+			a.recordFormField(info, scope, call, "[]string", false)
+		case "MultipartForm":
+			a.recordMultipartBody(info)
+		case "JSON", "IndentedJSON", "PureJSON", "SecureJSON", "AsciiJSON", "JSONP", "AbortWithStatusJSON":
+			a.recordResponse(info, scope, call, "")
+		case "XML", "YAML", "TOML":
+			a.recordResponse(info, scope, call, writerContentTypes[method])
+		case "ProtoBuf", "String", "HTML":
+			a.recordTextResponse(info, scope, call, method)
+		case "Data", "DataFromReader":
+			a.recordDataResponse(info, scope, call, method)
+		case "File", "FileFromFS", "FileAttachment":
+			a.recordFileResponse(info, scope, call, method)
+		case "Negotiate":
+			a.recordNegotiate(info, scope, call)
+		case "Stream":
+			a.recordEventStream(info, nil)
+		case "SSEvent":
+			a.recordServerSentEvent(info, scope, call)
+		case "Header", "SetCookie":
+			a.recordHeaderWrite(info, scope, call)
+		case "Cookie":
+			a.recordCookieRead(info, scope, call)
+		case "Redirect":
+			a.recordRedirect(info, scope, call)
+		case "Status", "AbortWithStatus", "AbortWithError":
+			a.recordStatus(info, scope, call)
+		}
+		return true
+	})
+}
+
+// analyzeHelperCall sigue las llamadas a funciones del propio código que reciben el
+// *gin.Context, como respondError(c, http.StatusNotFound, err)
+func (a *EnhancedHandlerAnalyzer) analyzeHelperCall(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	if scope.depth >= maxHelperDepth || !passesContext(call, scope.contextNames) {
+		return
+	}
+
+	fn := calledFunction(scope.pkg, call)
+	if fn == nil {
+		return
+	}
+
+	helperPkg, helperDecl := a.loader.Declaration(fn)
+	if helperDecl == nil {
+		return
+	}
+
+	signature := fn.Type().(*types.Signature)
+	helper := &bodyScope{
+		pkg:          helperPkg,
+		contextNames: make(map[string]bool),
+		args:         make(map[types.Object]callArgument),
+		depth:        scope.depth + 1,
+		headerWrites: scope.headerWrites,
+	}
+	for i, arg := range call.Args {
+		if i >= signature.Params().Len() || (signature.Variadic() && i >= signature.Params().Len()-1) {
+			break
+		}
+
+		param := signature.Params().At(i)
+		if ident, ok := arg.(*ast.Ident); ok && scope.contextNames[ident.Name] {
+			helper.contextNames[param.Name()] = true
+			continue
+		}
+		helper.args[param] = callArgument{scope: scope, expr: arg}
+	}
+
+	a.analyzeBody(info, helper, helperDecl)
+}
+
+// resolve sigue una expresión que nombra un parámetro del helper hasta el argumento
+// con el que se llamó, devolviendo el paquete donde debe evaluarse
+func (s *bodyScope) resolve(expr ast.Expr) (*PackageInfo, ast.Expr) {
+	scope := s
+	for {
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			return scope.pkg, expr
+		}
+
+		arg, exists := scope.args[scope.pkg.Info.Uses[ident]]
+		if !exists {
+			return scope.pkg, expr
+		}
+		scope, expr = arg.scope, arg.expr
+	}
+}
+
+// typeOf obtiene el tipo de una expresión resolviendo los parámetros del helper
+func (s *bodyScope) typeOf(expr ast.Expr) (types.Type, *PackageInfo) {
+	pkg, resolved := s.resolve(expr)
+	return pkg.Info.TypeOf(resolved), pkg
+}
+
+// statusCode resuelve el código de estado constante de una expresión del ámbito
+func (s *bodyScope) statusCode(expr ast.Expr) int {
+	pkg, resolved := s.resolve(expr)
+	return statusCode(pkg, resolved)
+}
+
+// passesContext indica si alguno de los argumentos es el *gin.Context
+func passesContext(call *ast.CallExpr, contextNames map[string]bool) bool {
+	for _, arg := range call.Args {
+		if ident, ok := arg.(*ast.Ident); ok && contextNames[ident.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// calledFunction obtiene la función o método invocado de forma estática
+func calledFunction(pkg *PackageInfo, call *ast.CallExpr) *types.Func {
+	var ident *ast.Ident
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+
+	fn, _ := pkg.Info.Uses[ident].(*types.Func)
+	return fn
+}
+
+// contextParamNames obtiene los nombres de los parámetros de tipo *gin.Context
+func (a *EnhancedHandlerAnalyzer) contextParamNames(funcDecl *ast.FuncDecl) map[string]bool {
+	names := make(map[string]bool)
+	a.addContextParams(names, funcDecl.Type)
+	return names
+}
+
+// addContextParams agrega los parámetros *gin.Context de una firma (función o literal)
+func (a *EnhancedHandlerAnalyzer) addContextParams(names map[string]bool, funcType *ast.FuncType) {
+	if funcType.Params == nil {
+		return
+	}
+
+	for _, param := range funcType.Params.List {
+		paramType := a.getTypeName(param.Type)
+		if a.determineParameterLocation("", paramType) != "context" {
+			continue
+		}
+		for _, name := range param.Names {
+			names[name.Name] = true
+		}
+	}
+}
+
+// contextMethod devuelve el método invocado cuando la llamada es del tipo c.Metodo(...)
+func contextMethod(call *ast.CallExpr, contextNames map[string]bool) (string, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok || !contextNames[ident.Name] {
+		return "", false
+	}
+
+	return selector.Sel.Name, true
+}
+
+// recordBodyBinding registra el struct enlazado con ShouldBindJSON como parámetro body;
+// contentTypes son los media types que acepta el binding (nil para JSON)
+func (a *EnhancedHandlerAnalyzer) recordBodyBinding(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, contentTypes []string) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	boundType, pkg := scope.typeOf(call.Args[0])
+	goType := derefType(boundType)
+	if goType == nil {
+		return
+	}
+
+	for i, param := range info.Params {
+		if param.Location == "body" && param.GoType != nil && types.Identical(param.GoType, goType) {
+			// Ya registrado: el mismo struct enlazado con otro binding suma sus media types
+			info.Params[i].ContentTypes = mergeContentTypes(param.ContentTypes, contentTypes)
+			if hasFormContentType(contentTypes) {
+				info.Params[i].BindingTag = "form"
+			}
+			return
+		}
+	}
+
+	param := ParamInfo{
+		Name:         boundVariableName(call.Args[0]),
+		Type:         typeString(goType, pkg),
+		Location:     "body",
+		Required:     true,
+		GoType:       goType,
+		ContentTypes: contentTypes,
+	}
+	if hasFormContentType(contentTypes) {
+		param.BindingTag = "form"
+	}
+	info.Params = append(info.Params, param)
+}
+
+// recordResponse registra el código de estado y el tipo del valor escrito con c.JSON,
+// c.XML y similares; contentType vacío es application/json
+func (a *EnhancedHandlerAnalyzer) recordResponse(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, contentType string) {
+	if len(call.Args) < 2 {
+		return
+	}
+
+	goType, pkg := scope.typeOf(call.Args[len(call.Args)-1])
+
+	info.Responses = append(info.Responses, ResponseInfo{
+		StatusCode:  scope.statusCode(call.Args[0]),
+		Type:        typeString(goType, pkg),
+		GoType:      goType,
+		ContentType: contentType,
+	})
+}
+
+// recordStatus registra una respuesta sin cuerpo (c.Status, c.AbortWithStatus, c.AbortWithError)
+func (a *EnhancedHandlerAnalyzer) recordStatus(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	info.Responses = append(info.Responses, ResponseInfo{
+		StatusCode: scope.statusCode(call.Args[0]),
+	})
+}
+
+// statusCode resuelve el valor constante de un código de estado (0 si no es constante)
+func statusCode(pkg *PackageInfo, expr ast.Expr) int {
+	tv, ok := pkg.Info.Types[expr]
+	if !ok || tv.Value == nil {
+		return 0
+	}
+
+	code, ok := constant.Int64Val(constant.ToInt(tv.Value))
+	if !ok {
+		return 0
+	}
+	return int(code)
+}
+
+// boundVariableName obtiene el nombre de la variable pasada como &req
+func boundVariableName(expr ast.Expr) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return "body"
+}
+
+// derefType elimina un nivel de puntero
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+// typeString representa un tipo relativo al paquete analizado ("User" en lugar de "main.User")
+func typeString(t types.Type, pkg *PackageInfo) string {
+	if t == nil {
+		return "unknown"
+	}
+	return types.TypeString(t, types.RelativeTo(pkg.Types))
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"go/ast"
+	"go/types"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// writerContentTypes son los media types de los writers de gin que no escriben JSON
+var writerContentTypes = map[string]string{
+	"XML":      "application/xml",
+	"YAML":     "application/yaml",
+	"TOML":     "application/toml",
+	"ProtoBuf": "application/x-protobuf",
+	"String":   "text/plain",
+	"HTML":     "text/html",
+}
+
+// mimeConstants son las constantes de gin y binding (gin.MIMEJSON, binding.MIMEXML);
+// se resuelven por nombre porque los tipos de gin no siempre se pueden cargar
+var mimeConstants = map[string]string{
+	"MIMEJSON":              "application/json",
+	"MIMEHTML":              "text/html",
+	"MIMEXML":               "application/xml",
+	"MIMEXML2":              "text/xml",
+	"MIMEPlain":             "text/plain",
+	"MIMEPOSTForm":          FormURLEncoded,
+	"MIMEMultipartPOSTForm": MultipartFormData,
+	"MIMEPROTOBUF":          "application/x-protobuf",
+	"MIMEMSGPACK":           "application/x-msgpack",
+	"MIMEMSGPACK2":          "application/msgpack",
+	"MIMEYAML":              "application/x-yaml",
+	"MIMEYAML2":             "application/yaml",
+	"MIMETOML":              "application/toml",
+}
+
+// negotiateData es el campo de gin.Negotiate que se escribe con cada formato ofrecido;
+// los formatos que c.Negotiate no sabe escribir responden 406
+var negotiateData = map[string]string{
+	"application/json":   "JSONData",
+	"text/html":          "HTMLData",
+	"application/xml":    "XMLData",
+	"application/x-yaml": "YAMLData",
+	"application/yaml":   "YAMLData",
+	"application/toml":   "TOMLData",
+}
+
+// recordTextResponse registra c.String y c.HTML como texto y c.ProtoBuf como binario
+func (a *EnhancedHandlerAnalyzer) recordTextResponse(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, method string) {
+	if len(call.Args) < 2 {
+		return
+	}
+
+	response := ResponseInfo{
+		StatusCode:  scope.statusCode(call.Args[0]),
+		ContentType: writerContentTypes[method],
+	}
+	if method == "ProtoBuf" {
+		goType, pkg := scope.typeOf(call.Args[1])
+		response.Type = typeString(goType, pkg)
+		response.Binary = true
+	} else {
+		response.Type = "string"
+		response.GoType = types.Typ[types.String]
+	}
+	info.Responses = append(info.Responses, response)
+}
+
+// recordDataResponse registra c.Data(code, contentType, data) y
+// c.DataFromReader(code, length, contentType, reader, extraHeaders)
+func (a *EnhancedHandlerAnalyzer) recordDataResponse(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, method string) {
+	contentTypeArg := 1
+	if method == "DataFromReader" {
+		contentTypeArg = 2
+	}
+	if len(call.Args) <= contentTypeArg {
+		return
+	}
+
+	response := rawResponse(scope.statusCode(call.Args[0]), scope.contentType(call.Args[contentTypeArg]))
+
+	// Los headers adicionales de DataFromReader (Content-Disposition y similares)
+	if method == "DataFromReader" && len(call.Args) == 5 {
+		pkg, extraHeaders := scope.resolve(call.Args[4])
+		if literal, ok := extraHeaders.(*ast.CompositeLit); ok {
+			for _, element := range literal.Elts {
+				if entry, ok := element.(*ast.KeyValueExpr); ok {
+					if name := constantString(pkg, entry.Key); name != "" {
+						response.Headers = append(response.Headers, HeaderInfo{Name: name, Value: constantString(pkg, entry.Value)})
+					}
+				}
+			}
+		}
+	}
+
+	info.Responses = append(info.Responses, response)
+}
+
+// recordFileResponse registra c.File, c.FileFromFS y c.FileAttachment; el media type se
+// deduce de la extensión cuando el nombre es constante y la descarga como adjunto
+// declara el header Content-Disposition
+func (a *EnhancedHandlerAnalyzer) recordFileResponse(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, method string) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	response := rawResponse(http.StatusOK, mediaTypeByExtension(scope.constantString(call.Args[0])))
+
+	if method == "FileAttachment" && len(call.Args) == 2 {
+		// El nombre de la descarga determina el media type que verá el cliente
+		filename := scope.constantString(call.Args[1])
+		if contentType := mediaTypeByExtension(filename); contentType != "" {
+			response = rawResponse(http.StatusOK, contentType)
+		}
+
+		value := ""
+		if filename != "" {
+			value = `attachment; filename="` + filename + `"`
+		}
+		response.Headers = append(response.Headers, HeaderInfo{Name: "Content-Disposition", Value: value})
+	}
+	info.Responses = append(info.Responses, response)
+}
+
+// recordNegotiate expande c.Negotiate(code, gin.Negotiate{Offered: ..., Data: ...}) en una
+// respuesta por formato ofrecido, más el 406 que gin responde si ninguno es aceptable
+func (a *EnhancedHandlerAnalyzer) recordNegotiate(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+
+	_, negotiate := scope.resolve(call.Args[1])
+	if unary, ok := negotiate.(*ast.UnaryExpr); ok {
+		negotiate = unary.X
+	}
+	literal, ok := negotiate.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+
+	fields := make(map[string]ast.Expr)
+	for _, element := range literal.Elts {
+		if entry, ok := element.(*ast.KeyValueExpr); ok {
+			if key, ok := entry.Key.(*ast.Ident); ok {
+				fields[key.Name] = entry.Value
+			}
+		}
+	}
+
+	offered, ok := fields["Offered"].(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+
+	statusCode := scope.statusCode(call.Args[0])
+	for _, element := range offered.Elts {
+		contentType := scope.contentType(element)
+		dataField, supported := negotiateData[contentType]
+		if !supported {
+			continue
+		}
+
+		data, exists := fields[dataField]
+		if !exists {
+			data = fields["Data"]
+		}
+
+		response := ResponseInfo{StatusCode: statusCode, ContentType: contentType}
+		switch {
+		case contentType == "text/html":
+			response.Type, response.GoType = "string", types.Typ[types.String]
+		case data != nil:
+			goType, pkg := scope.typeOf(data)
+			response.Type, response.GoType = typeString(goType, pkg), goType
+		}
+		info.Responses = append(info.Responses, response)
+	}
+
+	info.Responses = append(info.Responses, ResponseInfo{StatusCode: http.StatusNotAcceptable})
+}
+
+// rawResponse crea una respuesta sin estructura: texto para los media types textuales y
+// binaria para el resto (application/octet-stream si se desconoce)
+func rawResponse(statusCode int, contentType string) ResponseInfo {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	response := ResponseInfo{StatusCode: statusCode, ContentType: contentType}
+	if isTextualMediaType(contentType) {
+		response.Type, response.GoType = "string", types.Typ[types.String]
+	} else {
+		response.Binary = true
+	}
+	return response
+}
+
+// contentType resuelve un media type constante o una constante MIME de gin
+func (s *bodyScope) contentType(expr ast.Expr) string {
+	pkg, resolved := s.resolve(expr)
+	if value := constantString(pkg, resolved); value != "" {
+		return normalizeMediaType(value)
+	}
+	if selector, ok := resolved.(*ast.SelectorExpr); ok {
+		return mimeConstants[selector.Sel.Name]
+	}
+	return ""
+}
+
+// constantString resuelve el texto constante de una expresión del ámbito
+func (s *bodyScope) constantString(expr ast.Expr) string {
+	pkg, resolved := s.resolve(expr)
+	return constantString(pkg, resolved)
+}
+
+// mediaTypeByExtension deduce el media type de un nombre de archivo ("" si se desconoce)
+func mediaTypeByExtension(name string) string {
+	extension := path.Ext(name)
+	if extension == "" {
+		return ""
+	}
+	return normalizeMediaType(mime.TypeByExtension(extension))
+}
+
+// normalizeMediaType quita los parámetros del media type ("text/plain; charset=utf-8")
+func normalizeMediaType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.TrimSpace(contentType)
+}
+
+// isTextualMediaType indica si el cuerpo es texto legible en lugar de binario
+func isTextualMediaType(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.HasSuffix(contentType, "json") ||
+		strings.HasSuffix(contentType, "xml") ||
+		strings.HasSuffix(contentType, "yaml")
+}
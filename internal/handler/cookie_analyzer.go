@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// recordCookieRead registra c.Cookie("session") y c.Request.Cookie("session") como
+// parámetros cookie
+func (a *EnhancedHandlerAnalyzer) recordCookieRead(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Cookie" || len(call.Args) != 1 {
+		return
+	}
+	if !isContextPath(selector.X, scope.contextNames) && !isContextPath(selector.X, scope.contextNames, "Request") {
+		return
+	}
+
+	name := scope.constantString(call.Args[0])
+	if name == "" {
+		return
+	}
+
+	for _, param := range info.Params {
+		if param.Location == "cookie" && param.Name == name {
+			return // Ya registrado
+		}
+	}
+	info.Params = append(info.Params, ParamInfo{Name: name, Type: "string", Location: "cookie"})
+}
+
+// setCookieHeader reconoce c.SetCookie(name, value, maxAge, path, domain, secure, httpOnly)
+// y http.SetCookie(c.Writer, &http.Cookie{...}) y devuelve el header Set-Cookie con un
+// ejemplo armado con los atributos constantes
+func setCookieHeader(call *ast.CallExpr, selector *ast.SelectorExpr, contextNames map[string]bool, constant func(ast.Expr) string) (HeaderInfo, bool) {
+	fields := make(map[string]ast.Expr)
+
+	switch {
+	case isContextPath(selector.X, contextNames) && len(call.Args) == 7:
+		for i, field := range []string{"Name", "Value", "MaxAge", "Path", "Domain", "Secure", "HttpOnly"} {
+			fields[field] = call.Args[i]
+		}
+	case len(call.Args) == 2 && isContextPath(call.Args[0], contextNames, "Writer"):
+		cookie := call.Args[1]
+		if unary, ok := cookie.(*ast.UnaryExpr); ok {
+			cookie = unary.X
+		}
+		literal, ok := cookie.(*ast.CompositeLit)
+		if !ok {
+			return HeaderInfo{Name: "Set-Cookie"}, true
+		}
+		for _, element := range literal.Elts {
+			if entry, ok := element.(*ast.KeyValueExpr); ok {
+				if key, ok := entry.Key.(*ast.Ident); ok {
+					fields[key.Name] = entry.Value
+				}
+			}
+		}
+	default:
+		return HeaderInfo{}, false
+	}
+
+	header := HeaderInfo{Name: "Set-Cookie"}
+	name := ""
+	if fields["Name"] != nil {
+		name = constant(fields["Name"])
+	}
+	if name == "" {
+		return header, true
+	}
+
+	example := name + "="
+	if fields["Value"] != nil {
+		example += constant(fields["Value"])
+	}
+	for _, attribute := range []string{"Path", "Domain"} {
+		if fields[attribute] != nil {
+			if value := constant(fields[attribute]); value != "" {
+				example += "; " + attribute + "=" + value
+			}
+		}
+	}
+	for _, attribute := range []string{"Secure", "HttpOnly"} {
+		if ident, ok := fields[attribute].(*ast.Ident); ok && ident.Name == "true" {
+			example += "; " + attribute
+		}
+	}
+
+	header.Value = strings.TrimSpace(example)
+	return header, true
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Media types de los cuerpos de formulario
+const (
+	MultipartFormData = "multipart/form-data"
+	FormURLEncoded    = "application/x-www-form-urlencoded"
+)
+
+// recordFormBinding registra el struct enlazado con ShouldBind o ShouldBindWith según el
+// binding. ShouldBind elige el binding por el Content-Type, así que el struct se documenta
+// como JSON y como formulario; un struct con archivos solo puede llegar como multipart.
+func (a *EnhancedHandlerAnalyzer) recordFormBinding(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	// ShouldBindWith(&req, binding.Form)
+	if len(call.Args) >= 2 {
+		switch bindingName(call.Args[1]) {
+		case "JSON":
+			a.recordBodyBinding(info, scope, call, nil)
+		case "Form", "FormPost":
+			a.recordBodyBinding(info, scope, call, []string{FormURLEncoded})
+		case "FormMultipart":
+			a.recordBodyBinding(info, scope, call, []string{MultipartFormData})
+		case "Query":
+			a.recordParamBinding(info, scope, call, "query", "form")
+		case "Header":
+			a.recordParamBinding(info, scope, call, "header", "header")
+		}
+		return
+	}
+
+	boundType, _ := scope.typeOf(call.Args[0])
+	if hasFileField(derefType(boundType), make(map[*types.Struct]bool)) {
+		a.recordBodyBinding(info, scope, call, []string{MultipartFormData})
+		return
+	}
+	a.recordBodyBinding(info, scope, call, []string{"application/json", FormURLEncoded})
+}
+
+// recordParamBinding registra un struct cuyos campos son parámetros: ShouldBindQuery
+// (tags form), ShouldBindHeader (tags header) o ShouldBindUri (tags uri)
+func (a *EnhancedHandlerAnalyzer) recordParamBinding(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, location, tag string) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	boundType, pkg := scope.typeOf(call.Args[0])
+	goType := derefType(boundType)
+	if goType == nil {
+		return
+	}
+
+	for _, param := range info.Params {
+		if param.Location == location && param.GoType != nil && types.Identical(param.GoType, goType) {
+			return // Ya registrado
+		}
+	}
+
+	info.Params = append(info.Params, ParamInfo{
+		Name:       boundVariableName(call.Args[0]),
+		Type:       typeString(goType, pkg),
+		Location:   location,
+		GoType:     goType,
+		BindingTag: tag,
+	})
+}
+
+// recordFormField registra un campo de formulario leído directamente del contexto:
+// c.FormFile("avatar"), c.PostForm("name")
+func (a *EnhancedHandlerAnalyzer) recordFormField(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr, fieldType string, required bool) {
+	if len(call.Args) == 0 {
+		return
+	}
+
+	pkg, arg := scope.resolve(call.Args[0])
+	name := constantString(pkg, arg)
+	if name == "" {
+		return
+	}
+
+	for _, param := range info.Params {
+		if param.Location == "form" && param.Name == name {
+			return // Ya registrado
+		}
+	}
+
+	info.Params = append(info.Params, ParamInfo{
+		Name:     name,
+		Type:     fieldType,
+		Location: "form",
+		Required: required,
+	})
+}
+
+// recordMultipartBody registra un cuerpo multipart leído con c.MultipartForm(); los campos
+// concretos se agregan al encontrar form.File["x"] o form.Value["x"]
+func (a *EnhancedHandlerAnalyzer) recordMultipartBody(info *HandlerInfo) {
+	for _, param := range info.Params {
+		if param.Location == "body" && param.GoType == nil {
+			return // Ya registrado
+		}
+	}
+
+	info.Params = append(info.Params, ParamInfo{
+		Name:         "form",
+		Type:         "object",
+		Location:     "body",
+		ContentTypes: []string{MultipartFormData},
+	})
+}
+
+// trackMultipartForms recuerda las variables asignadas con form, err := c.MultipartForm()
+func (a *EnhancedHandlerAnalyzer) trackMultipartForms(forms map[types.Object]bool, scope *bodyScope, assign *ast.AssignStmt) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+		return
+	}
+
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if method, ok := contextMethod(call, scope.contextNames); !ok || method != "MultipartForm" {
+		return
+	}
+
+	if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+		if obj := scope.pkg.Info.ObjectOf(ident); obj != nil {
+			forms[obj] = true
+		}
+	}
+}
+
+// recordMultipartField registra los campos leídos de un *multipart.Form:
+// form.File["documents"] es una lista de archivos y form.Value["title"] de textos
+func (a *EnhancedHandlerAnalyzer) recordMultipartField(info *HandlerInfo, forms map[types.Object]bool, scope *bodyScope, index *ast.IndexExpr) {
+	selector, ok := index.X.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok || !forms[scope.pkg.Info.ObjectOf(ident)] {
+		return
+	}
+
+	name := constantString(scope.pkg, index.Index)
+	if name == "" {
+		return
+	}
+
+	var fieldType string
+	switch selector.Sel.Name {
+	case "File":
+		fieldType = "[]file"
+	case "Value":
+		fieldType = "[]string"
+	default:
+		return
+	}
+
+	for _, param := range info.Params {
+		if param.Location == "form" && param.Name == name {
+			return // Ya registrado
+		}
+	}
+	info.Params = append(info.Params, ParamInfo{Name: name, Type: fieldType, Location: "form"})
+}
+
+// bindingName devuelve el binding usado en ShouldBindWith(&req, binding.Form)
+func bindingName(expr ast.Expr) string {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != "binding" {
+		return ""
+	}
+	return selector.Sel.Name
+}
+
+// hasFileField indica si el struct tiene campos *multipart.FileHeader (o listas de ellos)
+func hasFileField(t types.Type, visiting map[*types.Struct]bool) bool {
+	structType, ok := t.Underlying().(*types.Struct)
+	if !ok || visiting[structType] {
+		return false
+	}
+	visiting[structType] = true
+
+	for i := 0; i < structType.NumFields(); i++ {
+		fieldType := structType.Field(i).Type()
+		if slice, ok := fieldType.(*types.Slice); ok {
+			fieldType = slice.Elem()
+		}
+		fieldType = derefType(fieldType)
+
+		if isFileHeader(fieldType) {
+			return true
+		}
+		if structType.Field(i).Embedded() && hasFileField(fieldType, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFileHeader reconoce multipart.FileHeader de mime/multipart
+func isFileHeader(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "mime/multipart" && named.Obj().Name() == "FileHeader"
+}
+
+// hasFormContentType indica si alguno de los media types es un formulario
+func hasFormContentType(contentTypes []string) bool {
+	for _, contentType := range contentTypes {
+		if contentType == FormURLEncoded || contentType == MultipartFormData {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeContentTypes une los media types de dos bindings del mismo struct; nil es JSON
+func mergeContentTypes(existing, added []string) []string {
+	if existing == nil && added == nil {
+		return nil
+	}
+	if existing == nil {
+		existing = []string{"application/json"}
+	}
+	if added == nil {
+		added = []string{"application/json"}
+	}
+
+	merged := append([]string{}, existing...)
+	for _, contentType := range added {
+		merged = appendName(merged, contentType)
+	}
+	return merged
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"go/ast"
+	"path/filepath"
+	"strings"
+)
+
+// headerWrite es un header de respuesta fijado por el handler; se documenta en las
+// respuestas escritas después (index es la cantidad de respuestas previas)
+type headerWrite struct {
+	index  int
+	header HeaderInfo
+}
+
+// MiddlewareHeaders devuelve los headers de respuesta que fija el middleware que empieza
+// en la posición indicada, como X-RateLimit-Remaining o X-Request-ID
+func (a *EnhancedHandlerAnalyzer) MiddlewareHeaders(filePath string, line, column int) []HeaderInfo {
+	pkg, err := a.loader.Load(filepath.Dir(filePath))
+	if err != nil || pkg.Types == nil {
+		return nil
+	}
+
+	expr := pkg.exprAt(a.fset, filePath, line, column)
+	if expr == nil {
+		return nil
+	}
+
+	reads := &authReads{}
+	a.scanMiddleware(reads, pkg, expr, 0)
+	return reads.responseHeaders
+}
+
+// recordHeaderWrite registra c.Header("X-Total-Count", ...), c.Writer.Header().Set/Add("ETag", ...)
+// o c.SetCookie(...) para las respuestas que le siguen
+func (a *EnhancedHandlerAnalyzer) recordHeaderWrite(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	header, ok := responseHeader(call, scope.contextNames, scope.constantString)
+	if !ok {
+		return
+	}
+	*scope.headerWrites = append(*scope.headerWrites, headerWrite{index: len(info.Responses), header: header})
+}
+
+// applyHeaderWrites agrega cada header a las respuestas escritas después de fijarlo
+func applyHeaderWrites(info *HandlerInfo, writes []headerWrite) {
+	for _, write := range writes {
+		for i := write.index; i < len(info.Responses); i++ {
+			info.Responses[i].Headers = appendHeader(info.Responses[i].Headers, write.header)
+		}
+	}
+}
+
+// responseHeader reconoce la escritura de un header de respuesta sobre el *gin.Context
+// (incluidas las cookies) y devuelve su nombre y su valor si es constante. Content-Type no se incluye porque se
+// documenta como media type.
+func responseHeader(call *ast.CallExpr, contextNames map[string]bool, constant func(ast.Expr) string) (HeaderInfo, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return HeaderInfo{}, false
+	}
+	if selector.Sel.Name == "SetCookie" {
+		return setCookieHeader(call, selector, contextNames, constant)
+	}
+	if len(call.Args) != 2 {
+		return HeaderInfo{}, false
+	}
+
+	switch selector.Sel.Name {
+	case "Header": // c.Header("X-Total-Count", total)
+		if !isContextPath(selector.X, contextNames) {
+			return HeaderInfo{}, false
+		}
+	case "Set", "Add": // c.Writer.Header().Set("ETag", etag)
+		header, ok := selector.X.(*ast.CallExpr)
+		if !ok {
+			return HeaderInfo{}, false
+		}
+		headerSelector, ok := header.Fun.(*ast.SelectorExpr)
+		if !ok || headerSelector.Sel.Name != "Header" || !isContextPath(headerSelector.X, contextNames, "Writer") {
+			return HeaderInfo{}, false
+		}
+	default:
+		return HeaderInfo{}, false
+	}
+
+	name := constant(call.Args[0])
+	if name == "" || strings.EqualFold(name, "Content-Type") {
+		return HeaderInfo{}, false
+	}
+	return HeaderInfo{Name: name, Value: constant(call.Args[1])}, true
+}
+
+// appendHeader agrega el header si la respuesta no lo declaraba
+func appendHeader(headers []HeaderInfo, header HeaderInfo) []HeaderInfo {
+	for _, existing := range headers {
+		if strings.EqualFold(existing.Name, header.Name) {
+			return headers
+		}
+	}
+	return append(headers, header)
+}
@@ -0,0 +1,415 @@
+package handler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PackageInfo agrupa los archivos parseados y la información de tipos de un paquete
+type PackageInfo struct {
+	Dir   string
+	Path  string
+	Name  string
+	Files []*ast.File
+	Types *types.Package
+	Info  *types.Info
+}
+
+// PackageLoader carga paquetes desde su directorio y resuelve sus tipos con go/types.
+// Los imports de la librería estándar y del propio módulo se resuelven; el resto no.
+type PackageLoader struct {
+	fset     *token.FileSet
+	stdlib   types.Importer
+	packages map[string]*PackageInfo
+	loading  map[string]bool
+	modules  map[string]moduleInfo // Directorio → módulo que lo contiene
+	docs     map[token.Pos]string  // Posición del identificador → comentario de documentación
+	analyzed map[string]bool       // Directorios cargados para analizarlos, no como import
+}
+
+// moduleInfo describe el módulo Go que contiene un directorio
+type moduleInfo struct {
+	Root string
+	Path string
+}
+
+// NewPackageLoader crea un cargador que comparte el FileSet del analizador
+func NewPackageLoader(fset *token.FileSet) *PackageLoader {
+	return &PackageLoader{
+		fset:     fset,
+		stdlib:   importer.Default(),
+		packages: make(map[string]*PackageInfo),
+		loading:  make(map[string]bool),
+		modules:  make(map[string]moduleInfo),
+		docs:     make(map[token.Pos]string),
+		analyzed: make(map[string]bool),
+	}
+}
+
+// Load parsea y verifica los tipos de todos los archivos Go de un directorio.
+// Los errores de tipos no detienen el análisis: los paquetes externos que no
+// se pueden importar simplemente producen tipos inválidos.
+func (l *PackageLoader) Load(dir string) (*PackageInfo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := l.load(dir)
+	if err == nil {
+		l.analyzed[dir] = true
+	}
+	return pkg, err
+}
+
+// load carga el paquete de un directorio absoluto, ya sea analizado o importado
+func (l *PackageLoader) load(dir string) (*PackageInfo, error) {
+	if pkg, exists := l.packages[dir]; exists {
+		return pkg, nil
+	}
+	if l.loading[dir] {
+		return nil, fmt.Errorf("import cycle through %s", dir)
+	}
+	l.loading[dir] = true
+	defer delete(l.loading, dir)
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &PackageInfo{Dir: dir}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(l.fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Files = append(pkg.Files, file)
+		l.indexDocs(file)
+	}
+
+	if len(pkg.Files) == 0 {
+		return nil, fmt.Errorf("no Go files found in %s", dir)
+	}
+	pkg.Name = pkg.Files[0].Name.Name
+	pkg.Path = l.importPath(dir, pkg.Name)
+
+	pkg.Info = &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+
+	config := types.Config{
+		Importer: l,
+		Error:    func(error) {}, // Ignorar errores para continuar con tipos parciales
+	}
+	pkg.Types, _ = config.Check(pkg.Path, l.fset, pkg.Files, pkg.Info)
+
+	l.packages[dir] = pkg
+	return pkg, nil
+}
+
+// Doc devuelve el comentario de la declaración de tipo o campo cuyo nombre está en pos
+func (l *PackageLoader) Doc(pos token.Pos) string {
+	return l.docs[pos]
+}
+
+// indexDocs registra los comentarios de tipos y campos por la posición de su nombre,
+// que coincide con la posición de los objetos de go/types
+func (l *PackageLoader) indexDocs(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.GenDecl:
+			if node.Tok != token.TYPE {
+				return true
+			}
+			for _, spec := range node.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(node.Specs) == 1 {
+					doc = node.Doc // type User struct{...} con el comentario sobre "type"
+				}
+				if doc != nil {
+					l.docs[typeSpec.Name.Pos()] = doc.Text()
+				}
+			}
+		case *ast.Field:
+			doc := node.Doc
+			if doc == nil {
+				doc = node.Comment // Comentario al final de la línea
+			}
+			if doc == nil {
+				return true
+			}
+			for _, name := range node.Names {
+				l.docs[name.Pos()] = doc.Text()
+			}
+		}
+		return true
+	})
+}
+
+// Import implementa types.Importer
+func (l *PackageLoader) Import(path string) (*types.Package, error) {
+	return l.ImportFrom(path, "", 0)
+}
+
+// ImportFrom resuelve la librería estándar y los paquetes del módulo que contiene
+// al archivo que importa; el resto de paquetes queda sin resolver
+func (l *PackageLoader) ImportFrom(path, dir string, _ types.ImportMode) (*types.Package, error) {
+	if isStandardLibrary(path) {
+		return l.stdlib.Import(path)
+	}
+
+	if module, ok := l.findModule(dir); ok && module.Path != "" {
+		if rel, ok := strings.CutPrefix(path, module.Path); ok && (rel == "" || strings.HasPrefix(rel, "/")) {
+			pkg, err := l.load(filepath.Join(module.Root, filepath.FromSlash(rel)))
+			if err != nil {
+				return nil, err
+			}
+			if pkg.Types == nil {
+				return nil, fmt.Errorf("package %s could not be type-checked", path)
+			}
+			return pkg.Types, nil
+		}
+	}
+
+	return nil, fmt.Errorf("package %s is not loaded", path)
+}
+
+// importPath calcula la ruta de importación de un directorio a partir de su go.mod
+func (l *PackageLoader) importPath(dir, packageName string) string {
+	module, ok := l.findModule(dir)
+	if !ok {
+		return packageName
+	}
+
+	rel, err := filepath.Rel(module.Root, dir)
+	if err != nil || rel == "." {
+		return module.Path
+	}
+	return module.Path + "/" + filepath.ToSlash(rel)
+}
+
+// findModule busca hacia arriba el go.mod que contiene al directorio
+func (l *PackageLoader) findModule(dir string) (moduleInfo, bool) {
+	if dir == "" {
+		return moduleInfo{}, false
+	}
+	if module, exists := l.modules[dir]; exists {
+		return module, module.Root != ""
+	}
+
+	module := moduleInfo{}
+	for current := dir; ; current = filepath.Dir(current) {
+		if data, err := os.ReadFile(filepath.Join(current, "go.mod")); err == nil {
+			module = moduleInfo{Root: current, Path: modulePath(data)}
+			break
+		}
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+
+	l.modules[dir] = module
+	return module, module.Root != ""
+}
+
+// modulePath extrae la directiva module de un go.mod
+func modulePath(goMod []byte) string {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// Implementations busca los tipos concretos que implementan la interfaz, ya sea con
+// receptor de valor o de puntero, en los paquetes analizados y los que importan
+func (l *PackageLoader) Implementations(iface *types.Interface) []*types.Named {
+	var implementations []*types.Named
+	for _, pkg := range l.reachablePackages() {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || typeName.IsAlias() {
+				continue
+			}
+
+			named, ok := typeName.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+				continue
+			}
+
+			if types.Implements(named, iface) || types.Implements(types.NewPointer(named), iface) {
+				implementations = append(implementations, named)
+			}
+		}
+	}
+
+	return implementations
+}
+
+// reachablePackages devuelve, ordenados por ruta, los paquetes analizados y los paquetes
+// cargados desde el código fuente que importan directa o indirectamente
+func (l *PackageLoader) reachablePackages() []*types.Package {
+	seen := make(map[*types.Package]bool)
+	var visit func(pkg *types.Package)
+	visit = func(pkg *types.Package) {
+		if pkg == nil || seen[pkg] || !l.isLoaded(pkg) {
+			return
+		}
+		seen[pkg] = true
+		for _, imported := range pkg.Imports() {
+			visit(imported)
+		}
+	}
+	for dir := range l.analyzed {
+		visit(l.packages[dir].Types)
+	}
+
+	packages := make([]*types.Package, 0, len(seen))
+	for pkg := range seen {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Path() < packages[j].Path()
+	})
+	return packages
+}
+
+// Constants devuelve las constantes declaradas con el tipo indicado, en orden de
+// declaración. Solo se consideran los paquetes cargados desde el código fuente.
+func (l *PackageLoader) Constants(named *types.Named) []*types.Const {
+	pkg := named.Obj().Pkg()
+	if pkg == nil || !l.isLoaded(pkg) {
+		return nil
+	}
+
+	var constants []*types.Const
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if declared, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(declared.Type(), named) {
+			constants = append(constants, declared)
+		}
+	}
+
+	sort.Slice(constants, func(i, j int) bool {
+		return constants[i].Pos() < constants[j].Pos()
+	})
+	return constants
+}
+
+// Declaration busca la declaración de una función de un paquete cargado desde el código fuente
+func (l *PackageLoader) Declaration(fn *types.Func) (*PackageInfo, *ast.FuncDecl) {
+	for _, pkg := range l.packages {
+		if pkg.Types == nil || pkg.Types != fn.Pkg() {
+			continue
+		}
+
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Name.Pos() == fn.Pos() {
+					return pkg, funcDecl
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// LookupType busca un tipo con nombre en los paquetes cargados. El nombre puede ser
+// simple (ErrorResponse), con el nombre del paquete (api.ErrorResponse) o con la ruta
+// de importación completa (example.com/app/api.ErrorResponse).
+func (l *PackageLoader) LookupType(name string) *types.Named {
+	qualifier, typeName := "", name
+	if dot := strings.LastIndex(name, "."); dot != -1 {
+		qualifier, typeName = name[:dot], name[dot+1:]
+	}
+
+	dirs := make([]string, 0, len(l.packages))
+	for dir := range l.packages {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		pkg := l.packages[dir]
+		if pkg.Types == nil {
+			continue
+		}
+		if qualifier != "" && qualifier != pkg.Types.Name() && qualifier != pkg.Types.Path() {
+			continue
+		}
+
+		if typeName, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName); ok {
+			if named, ok := typeName.Type().(*types.Named); ok {
+				return named
+			}
+		}
+	}
+	return nil
+}
+
+// isLoaded indica si el paquete fue verificado desde el código fuente por este cargador
+func (l *PackageLoader) isLoaded(pkg *types.Package) bool {
+	for _, loaded := range l.packages {
+		if loaded.Types == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// FindFunction busca la declaración de una función o método dentro del paquete
+func (p *PackageInfo) FindFunction(receiver, name string) *ast.FuncDecl {
+	for _, file := range p.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != name {
+				continue
+			}
+
+			if receiverName(funcDecl) == receiver {
+				return funcDecl
+			}
+		}
+	}
+	return nil
+}
+
+// receiverName obtiene el nombre del tipo receptor de un método ("" para funciones)
+func receiverName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := funcDecl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// isStandardLibrary indica si una ruta de importación pertenece a la librería estándar
+func isStandardLibrary(path string) bool {
+	firstElem := strings.Split(path, "/")[0]
+	return !strings.Contains(firstElem, ".")
+}
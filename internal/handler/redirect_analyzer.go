@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"go/ast"
+	"go/token"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// formatVerb reconoce los verbos de fmt.Sprintf que se reemplazan por parámetros y el %% literal
+var formatVerb = regexp.MustCompile(`%%|%[-+# 0-9.]*[a-zA-Z]`)
+
+// recordRedirect registra c.Redirect(code, location) como una respuesta 3xx con el
+// header Location; el destino se documenta si es constante o se arma sobre una ruta
+func (a *EnhancedHandlerAnalyzer) recordRedirect(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+
+	response := ResponseInfo{StatusCode: scope.statusCode(call.Args[0])}
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusFound // Código no constante: se documenta la redirección habitual
+	}
+	location := HeaderInfo{Name: "Location"}
+
+	pkg, target := scope.resolve(call.Args[1])
+	if value := constantString(pkg, target); value != "" {
+		location.Value = value
+		response.Description = "Redirects to " + value
+	} else if path := redirectPath(pkg, target); strings.Contains(path, "/") {
+		response.Description = "Redirects to " + path
+	}
+
+	response.Headers = append(response.Headers, location)
+	info.Responses = append(info.Responses, response)
+}
+
+// redirectPath reconstruye el destino de una redirección armada con concatenaciones o
+// fmt.Sprintf, nombrando las partes variables como parámetros ("/users/" + id → /users/{id})
+func redirectPath(pkg *PackageInfo, expr ast.Expr) string {
+	if value := constantString(pkg, expr); value != "" {
+		return value
+	}
+
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return redirectPath(pkg, x.X)
+	case *ast.BinaryExpr:
+		if x.Op == token.ADD {
+			return redirectPath(pkg, x.X) + redirectPath(pkg, x.Y)
+		}
+	case *ast.CallExpr:
+		if fn := calledFunction(pkg, x); fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && fn.Name() == "Sprintf" && len(x.Args) > 0 {
+			format := constantString(pkg, x.Args[0])
+			if format == "" {
+				break
+			}
+
+			args := x.Args[1:]
+			return formatVerb.ReplaceAllStringFunc(format, func(verb string) string {
+				if verb == "%%" {
+					return "%"
+				}
+				if len(args) == 0 {
+					return "{value}"
+				}
+				arg := args[0]
+				args = args[1:]
+				return redirectPath(pkg, arg)
+			})
+		}
+	}
+	return "{" + placeholderName(pkg, expr) + "}"
+}
+
+// placeholderName nombra la parte variable de un destino: la variable, el campo o el
+// parámetro leído (c.Param("id") → id)
+func placeholderName(pkg *PackageInfo, expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		return x.Sel.Name
+	case *ast.CallExpr:
+		if len(x.Args) > 0 {
+			if name := constantString(pkg, x.Args[0]); name != "" {
+				return name
+			}
+		}
+	}
+	return "value"
+}
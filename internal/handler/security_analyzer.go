@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+// SecurityInfo describe un esquema de autenticación exigido por un middleware
+type SecurityInfo struct {
+	Type string // basic, bearer o apiKey
+	In   string // header, query o cookie (solo apiKey)
+	Name string // Header o parámetro que contiene la clave (solo apiKey)
+}
+
+// authReads acumula lo que un middleware lee de la petición y los headers que fija
+// en la respuesta
+type authReads struct {
+	basic   bool
+	bearer  bool
+	headers []string
+	query   []string
+	cookies []string
+
+	responseHeaders []HeaderInfo
+}
+
+// AnalyzeMiddleware detecta la autenticación que exige la expresión de middleware que
+// empieza en la posición indicada. Devuelve las alternativas aceptadas (por ejemplo, la
+// clave por header o por query) o nil si el middleware no autentica.
+func (a *EnhancedHandlerAnalyzer) AnalyzeMiddleware(filePath string, line, column int) []SecurityInfo {
+	pkg, err := a.loader.Load(filepath.Dir(filePath))
+	if err != nil || pkg.Types == nil {
+		return nil
+	}
+
+	expr := pkg.exprAt(a.fset, filePath, line, column)
+	if expr == nil {
+		return nil
+	}
+
+	// gin.BasicAuth(accounts) y gin.BasicAuthForRealm(accounts, realm)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == "gin" &&
+				(selector.Sel.Name == "BasicAuth" || selector.Sel.Name == "BasicAuthForRealm") {
+				return []SecurityInfo{{Type: "basic"}}
+			}
+		}
+	}
+
+	reads := &authReads{}
+	a.scanMiddleware(reads, pkg, expr, 0)
+	return reads.schemes()
+}
+
+// MiddlewareArguments devuelve los argumentos de texto constantes de un constructor de
+// middleware como RequireScopes("users:read", "users:write") o RequireRole(RoleAdmin).
+// También acepta un []string literal; los argumentos no constantes se ignoran.
+func (a *EnhancedHandlerAnalyzer) MiddlewareArguments(filePath string, line, column int) []string {
+	pkg, err := a.loader.Load(filepath.Dir(filePath))
+	if err != nil || pkg.Types == nil {
+		return nil
+	}
+
+	call, ok := pkg.exprAt(a.fset, filePath, line, column).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, arg := range call.Args {
+		if literal, ok := arg.(*ast.CompositeLit); ok {
+			for _, element := range literal.Elts {
+				values = appendName(values, constantString(pkg, element))
+			}
+			continue
+		}
+		values = appendName(values, constantString(pkg, arg))
+	}
+	return values
+}
+
+// scanMiddleware analiza el middleware: un literal de función, una función usada como
+// handler (AuthMiddleware) o un constructor que devuelve el handler (AuthRequired())
+func (a *EnhancedHandlerAnalyzer) scanMiddleware(reads *authReads, pkg *PackageInfo, expr ast.Expr, depth int) {
+	if depth >= maxHelperDepth {
+		return
+	}
+
+	var fn *types.Func
+	switch x := expr.(type) {
+	case *ast.FuncLit:
+		a.scanAuthReads(reads, pkg, x, depth)
+		return
+	case *ast.CallExpr:
+		fn = calledFunction(pkg, x)
+	case *ast.Ident:
+		fn, _ = pkg.Info.Uses[x].(*types.Func)
+	case *ast.SelectorExpr:
+		fn, _ = pkg.Info.Uses[x.Sel].(*types.Func)
+	}
+	if fn == nil {
+		return
+	}
+
+	if declPkg, funcDecl := a.loader.Declaration(fn); funcDecl != nil && funcDecl.Body != nil {
+		a.scanAuthReads(reads, declPkg, funcDecl, depth)
+	}
+}
+
+// scanAuthReads busca en una función (y en los literales que contiene) las lecturas de
+// credenciales sobre el *gin.Context: headers, query y c.Request.BasicAuth()
+func (a *EnhancedHandlerAnalyzer) scanAuthReads(reads *authReads, pkg *PackageInfo, node ast.Node, depth int) {
+	contextNames := make(map[string]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.FuncDecl:
+			a.addContextParams(contextNames, x.Type)
+		case *ast.FuncLit:
+			a.addContextParams(contextNames, x.Type)
+		case *ast.BasicLit:
+			if strings.Contains(x.Value, "Bearer") {
+				reads.bearer = true
+			}
+		case *ast.CallExpr:
+			if header, ok := responseHeader(x, contextNames, func(expr ast.Expr) string { return constantString(pkg, expr) }); ok {
+				reads.responseHeaders = appendHeader(reads.responseHeaders, header)
+				return true
+			}
+			if !a.recordAuthRead(reads, pkg, x, contextNames) && passesContext(x, contextNames) {
+				// Helpers como extractToken(c)
+				a.scanMiddleware(reads, pkg, x, depth+1)
+			}
+		}
+		return true
+	})
+}
+
+// recordAuthRead reconoce una lectura de credenciales y la acumula
+func (a *EnhancedHandlerAnalyzer) recordAuthRead(reads *authReads, pkg *PackageInfo, call *ast.CallExpr, contextNames map[string]bool) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	switch selector.Sel.Name {
+	case "BasicAuth": // c.Request.BasicAuth()
+		if isContextPath(selector.X, contextNames, "Request") {
+			reads.basic = true
+			return true
+		}
+	case "GetHeader": // c.GetHeader("Authorization")
+		if isContextPath(selector.X, contextNames) && len(call.Args) == 1 {
+			reads.headers = appendName(reads.headers, constantString(pkg, call.Args[0]))
+			return true
+		}
+	case "Cookie": // c.Cookie("session") o c.Request.Cookie("session")
+		if (isContextPath(selector.X, contextNames) || isContextPath(selector.X, contextNames, "Request")) && len(call.Args) == 1 {
+			reads.cookies = appendName(reads.cookies, constantString(pkg, call.Args[0]))
+			return true
+		}
+	case "Query", "DefaultQuery", "GetQuery": // c.Query("api_key")
+		if isContextPath(selector.X, contextNames) && len(call.Args) >= 1 {
+			reads.query = appendName(reads.query, constantString(pkg, call.Args[0]))
+			return true
+		}
+	case "Get":
+		if len(call.Args) != 1 {
+			return false
+		}
+		// c.Request.Header.Get("X-API-Key")
+		if isContextPath(selector.X, contextNames, "Request", "Header") {
+			reads.headers = appendName(reads.headers, constantString(pkg, call.Args[0]))
+			return true
+		}
+		// c.Request.URL.Query().Get("api_key")
+		if query, ok := selector.X.(*ast.CallExpr); ok {
+			if querySelector, ok := query.Fun.(*ast.SelectorExpr); ok && querySelector.Sel.Name == "Query" &&
+				isContextPath(querySelector.X, contextNames, "Request", "URL") {
+				reads.query = appendName(reads.query, constantString(pkg, call.Args[0]))
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// schemes convierte las lecturas en esquemas: Authorization con "Bearer" es bearer,
+// y los headers, parámetros o cookies con "key", "token" o "auth" en el nombre son API keys
+func (r *authReads) schemes() []SecurityInfo {
+	var schemes []SecurityInfo
+	if r.basic {
+		schemes = append(schemes, SecurityInfo{Type: "basic"})
+	}
+
+	for _, header := range r.headers {
+		switch {
+		case strings.EqualFold(header, "Authorization") && r.bearer:
+			schemes = append(schemes, SecurityInfo{Type: "bearer"})
+		case strings.EqualFold(header, "Authorization") && r.basic:
+			// Ya documentado como basic
+		case isCredentialName(header):
+			schemes = append(schemes, SecurityInfo{Type: "apiKey", In: "header", Name: header})
+		}
+	}
+
+	for _, param := range r.query {
+		if isCredentialName(param) {
+			schemes = append(schemes, SecurityInfo{Type: "apiKey", In: "query", Name: param})
+		}
+	}
+
+	// Las cookies de sesión también son credenciales (session, sid)
+	for _, cookie := range r.cookies {
+		lower := strings.ToLower(cookie)
+		if isCredentialName(cookie) || strings.Contains(lower, "session") || lower == "sid" {
+			schemes = append(schemes, SecurityInfo{Type: "apiKey", In: "cookie", Name: cookie})
+		}
+	}
+	return schemes
+}
+
+// isCredentialName indica si un header o parámetro transporta una credencial
+func isCredentialName(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "key") || strings.Contains(lower, "token") || strings.Contains(lower, "auth")
+}
+
+// isContextPath verifica que la expresión sea c o c.Campo1.Campo2... con c un *gin.Context
+func isContextPath(expr ast.Expr, contextNames map[string]bool, fields ...string) bool {
+	for i := len(fields) - 1; i >= 0; i-- {
+		selector, ok := expr.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != fields[i] {
+			return false
+		}
+		expr = selector.X
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	return ok && contextNames[ident.Name]
+}
+
+// constantString obtiene el valor de un argumento de texto constante ("" si no lo es)
+func constantString(pkg *PackageInfo, expr ast.Expr) string {
+	tv, ok := pkg.Info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return ""
+	}
+	return constant.StringVal(tv.Value)
+}
+
+func appendName(names []string, name string) []string {
+	if name == "" {
+		return names
+	}
+	for _, existing := range names {
+		if existing == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
+
+// exprAt busca la expresión más externa que empieza en la línea y columna indicadas
+func (p *PackageInfo) exprAt(fset *token.FileSet, filePath string, line, column int) ast.Expr {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil
+	}
+
+	for _, file := range p.Files {
+		tokenFile := fset.File(file.Pos())
+		if tokenFile == nil || tokenFile.Name() != absPath || line < 1 || line > tokenFile.LineCount() {
+			continue
+		}
+
+		pos := tokenFile.LineStart(line) + token.Pos(column-1)
+		var found ast.Expr
+		ast.Inspect(file, func(n ast.Node) bool {
+			if found != nil || n == nil || n.Pos() > pos || n.End() <= pos {
+				return found == nil
+			}
+			if expr, ok := n.(ast.Expr); ok && n.Pos() == pos {
+				found = expr
+				return false
+			}
+			return true
+		})
+		return found
+	}
+	return nil
+}
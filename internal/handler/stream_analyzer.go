@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"go/ast"
+	"go/types"
+	"net/http"
+)
+
+// EventStream es el media type de los server-sent events
+const EventStream = "text/event-stream"
+
+// recordServerSentEvent registra c.SSEvent("message", payload) con el nombre del evento
+// cuando es constante y el tipo del payload
+func (a *EnhancedHandlerAnalyzer) recordServerSentEvent(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+
+	goType, pkg := scope.typeOf(call.Args[1])
+	a.recordEventStream(info, &EventInfo{
+		Name:   scope.constantString(call.Args[0]),
+		Type:   typeString(goType, pkg),
+		GoType: goType,
+	})
+}
+
+// recordEventStream registra la respuesta en streaming (c.Stream, c.SSEvent o
+// c.Writer.Flush()) y le agrega el evento si se conoce; todos los eventos del handler
+// se documentan en la misma respuesta
+func (a *EnhancedHandlerAnalyzer) recordEventStream(info *HandlerInfo, event *EventInfo) {
+	index := -1
+	for i, response := range info.Responses {
+		if response.ContentType == EventStream {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		info.Responses = append(info.Responses, ResponseInfo{
+			StatusCode:  http.StatusOK,
+			Type:        "string",
+			GoType:      types.Typ[types.String],
+			ContentType: EventStream,
+		})
+		index = len(info.Responses) - 1
+	}
+
+	if event == nil {
+		return
+	}
+	for _, existing := range info.Responses[index].Events {
+		if existing.Name == event.Name && existing.Type == event.Type {
+			return // Ya registrado
+		}
+	}
+	info.Responses[index].Events = append(info.Responses[index].Events, *event)
+}
+
+// isWriterFlush reconoce c.Writer.Flush(), que envía la respuesta por partes
+func isWriterFlush(call *ast.CallExpr, contextNames map[string]bool) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && selector.Sel.Name == "Flush" && isContextPath(selector.X, contextNames, "Writer")
+}
@@ -4,18 +4,23 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
+	"path/filepath"
 	"strings"
 )
 
 // EnhancedHandlerAnalyzer extiende el analizador con inferencia avanzada
 type EnhancedHandlerAnalyzer struct {
 	*HandlerAnalyzer
+	loader *PackageLoader
 }
 
 // NewEnhancedHandlerAnalyzer crea un analizador mejorado
 func NewEnhancedHandlerAnalyzer() *EnhancedHandlerAnalyzer {
+	base := NewHandlerAnalyzer()
 	return &EnhancedHandlerAnalyzer{
-		HandlerAnalyzer: NewHandlerAnalyzer(),
+		HandlerAnalyzer: base,
+		loader:          NewPackageLoader(base.fset),
 	}
 }
 
@@ -47,6 +52,62 @@ func (a *EnhancedHandlerAnalyzer) AnalyzeMethod(packageName, filePath, structNam
 	return info, nil
 }
 
+// Implementations devuelve los tipos de los paquetes analizados que implementan la interfaz
+func (a *EnhancedHandlerAnalyzer) Implementations(iface *types.Interface) []*types.Named {
+	return a.loader.Implementations(iface)
+}
+
+// Doc devuelve el comentario de documentación de la declaración en pos
+func (a *EnhancedHandlerAnalyzer) Doc(pos token.Pos) string {
+	return a.loader.Doc(pos)
+}
+
+// Constants devuelve las constantes declaradas con el tipo indicado
+func (a *EnhancedHandlerAnalyzer) Constants(named *types.Named) []*types.Const {
+	return a.loader.Constants(named)
+}
+
+// LookupType busca un tipo con nombre en los paquetes analizados
+func (a *EnhancedHandlerAnalyzer) LookupType(name string) *types.Named {
+	return a.loader.LookupType(name)
+}
+
+// ResolveVariableType obtiene el nombre del tipo (sin puntero) de una variable
+// visible en la línea indicada, p.ej. handler := &APIHandler{} → "APIHandler"
+func (a *EnhancedHandlerAnalyzer) ResolveVariableType(filePath string, line int, name string) string {
+	pkg, err := a.loader.Load(filepath.Dir(filePath))
+	if err != nil || pkg.Types == nil {
+		return ""
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return ""
+	}
+
+	for _, file := range pkg.Files {
+		tokenFile := a.fset.File(file.Pos())
+		if tokenFile == nil || tokenFile.Name() != absPath || line < 1 || line > tokenFile.LineCount() {
+			continue
+		}
+
+		pos := tokenFile.LineStart(line)
+		scope := pkg.Types.Scope().Innermost(pos)
+		if scope == nil {
+			return ""
+		}
+
+		if _, obj := scope.LookupParent(name, pos); obj != nil {
+			if named, ok := derefType(obj.Type()).(*types.Named); ok {
+				return named.Obj().Name()
+			}
+		}
+		return ""
+	}
+
+	return ""
+}
+
 // enhanceHandlerInfo mejora la información del handler con inferencia avanzada
 func (a *EnhancedHandlerAnalyzer) enhanceHandlerInfo(info *HandlerInfo, filePath string) {
 	// Mejorar análisis de parámetros
@@ -54,6 +115,23 @@ func (a *EnhancedHandlerAnalyzer) enhanceHandlerInfo(info *HandlerInfo, filePath
 		a.enhanceParameterInfo(&info.Params[i], filePath)
 	}
 
+	// Analizar el cuerpo con información de tipos: bindings y respuestas reales
+	if pkg, err := a.loader.Load(filepath.Dir(filePath)); err == nil {
+		if funcDecl := pkg.FindFunction(info.Receiver, info.Name); funcDecl != nil {
+			a.analyzeHandlerBody(info, pkg, funcDecl)
+		}
+	}
+
+	// Usar el tipo de la primera respuesta exitosa como tipo de retorno
+	if info.ReturnType == "" {
+		for _, response := range info.Responses {
+			if response.StatusCode >= 200 && response.StatusCode < 300 && response.GoType != nil {
+				info.ReturnType = response.Type
+				break
+			}
+		}
+	}
+
 	// Inferir tipo de retorno si es desconocido
 	if info.ReturnType == "unknown" || info.ReturnType == "" {
 		info.ReturnType = a.inferReturnType(info, filePath)
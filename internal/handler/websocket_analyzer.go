@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"go/ast"
+	"go/types"
+	"net/http"
+)
+
+// WebSocketInfo describe la conexión WebSocket que abre el handler y los mensajes JSON
+// que lee y escribe sobre ella
+type WebSocketInfo struct {
+	Library  string
+	Receives []MessageInfo
+	Sends    []MessageInfo
+}
+
+// MessageInfo es el tipo de un mensaje leído o escrito por el WebSocket
+type MessageInfo struct {
+	Type   string
+	GoType types.Type
+}
+
+// Librerías de WebSocket reconocidas
+const (
+	GorillaWebSocket = "github.com/gorilla/websocket"
+	NhooyrWebSocket  = "nhooyr.io/websocket"
+)
+
+// wsjsonPackages son los paquetes con las funciones Read y Write de mensajes JSON de nhooyr
+var wsjsonPackages = map[string]bool{
+	NhooyrWebSocket + "/wsjson":         true,
+	"github.com/coder/websocket/wsjson": true,
+}
+
+// recordWebSocket registra el upgrade de la conexión: upgrader.Upgrade(c.Writer,
+// c.Request, nil) de gorilla o websocket.Accept(c.Writer, c.Request, nil) de nhooyr
+// responden 101 Switching Protocols
+func (a *EnhancedHandlerAnalyzer) recordWebSocket(info *HandlerInfo, scope *bodyScope, call *ast.CallExpr) {
+	library := GorillaWebSocket
+	if selector := call.Fun.(*ast.SelectorExpr); selector.Sel.Name == "Accept" {
+		library = NhooyrWebSocket
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			if pkgName, ok := scope.pkg.Info.Uses[ident].(*types.PkgName); ok {
+				library = pkgName.Imported().Path() // También github.com/coder/websocket
+			}
+		}
+	}
+
+	if info.WebSocket == nil {
+		info.WebSocket = &WebSocketInfo{}
+		info.Responses = append(info.Responses, ResponseInfo{
+			StatusCode: http.StatusSwitchingProtocols,
+			Headers: []HeaderInfo{
+				{Name: "Upgrade", Value: "websocket"},
+				{Name: "Connection", Value: "Upgrade"},
+			},
+		})
+	}
+	info.WebSocket.Library = library
+}
+
+// trackWebSocketConns recuerda las variables asignadas con conn, err := upgrader.Upgrade(...)
+func (a *EnhancedHandlerAnalyzer) trackWebSocketConns(conns map[types.Object]bool, scope *bodyScope, assign *ast.AssignStmt) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+		return
+	}
+
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || !isWebSocketUpgrade(call, scope.contextNames) {
+		return
+	}
+
+	if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+		if obj := scope.pkg.Info.ObjectOf(ident); obj != nil {
+			conns[obj] = true
+		}
+	}
+}
+
+// recordWebSocketMessage registra los mensajes JSON de la conexión: conn.ReadJSON(&msg) y
+// conn.WriteJSON(msg) de gorilla o wsjson.Read(ctx, conn, &msg) y wsjson.Write(ctx, conn, msg)
+func (a *EnhancedHandlerAnalyzer) recordWebSocketMessage(info *HandlerInfo, conns map[types.Object]bool, scope *bodyScope, call *ast.CallExpr) {
+	if info.WebSocket == nil {
+		return
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	receiver, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	var conn, message ast.Expr
+	switch {
+	case isWSJSONPackage(scope, receiver) && len(call.Args) == 3:
+		conn, message = call.Args[1], call.Args[2]
+	case len(call.Args) == 1:
+		conn, message = receiver, call.Args[0]
+	default:
+		return
+	}
+
+	ident, ok := conn.(*ast.Ident)
+	if !ok || !conns[scope.pkg.Info.ObjectOf(ident)] {
+		return
+	}
+
+	goType, pkg := scope.typeOf(message)
+	goType = derefType(goType)
+	if goType == nil {
+		return
+	}
+	found := MessageInfo{Type: typeString(goType, pkg), GoType: goType}
+
+	switch selector.Sel.Name {
+	case "ReadJSON", "Read":
+		info.WebSocket.Receives = appendMessage(info.WebSocket.Receives, found)
+	case "WriteJSON", "Write":
+		info.WebSocket.Sends = appendMessage(info.WebSocket.Sends, found)
+	}
+}
+
+// isWSJSONPackage indica si el identificador nombra al paquete wsjson, con cualquier alias de import
+func isWSJSONPackage(scope *bodyScope, ident *ast.Ident) bool {
+	pkgName, ok := scope.pkg.Info.Uses[ident].(*types.PkgName)
+	return ok && wsjsonPackages[pkgName.Imported().Path()]
+}
+
+// isWebSocketUpgrade reconoce las llamadas que reciben c.Writer y c.Request para
+// abrir un WebSocket: Upgrade (gorilla) o Accept (nhooyr)
+func isWebSocketUpgrade(call *ast.CallExpr, contextNames map[string]bool) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (selector.Sel.Name != "Upgrade" && selector.Sel.Name != "Accept") || len(call.Args) < 2 {
+		return false
+	}
+	return isContextPath(call.Args[0], contextNames, "Writer") && isContextPath(call.Args[1], contextNames, "Request")
+}
+
+// appendMessage agrega el tipo de mensaje si no estaba registrado
+func appendMessage(messages []MessageInfo, message MessageInfo) []MessageInfo {
+	for _, existing := range messages {
+		if types.Identical(existing.GoType, message.GoType) {
+			return messages
+		}
+	}
+	return append(messages, message)
+}
@@ -16,6 +16,7 @@ type RouteInfo struct {
 	HandlerType string
 	File        string
 	Line        int
+	Middleware  []MiddlewareInfo // Middlewares del engine, de los grupos y de la ruta, en orden
 }
 
 type GinAnalyzer struct {
@@ -27,50 +28,39 @@ func NewGinAnalyzer() *GinAnalyzer {
 }
 
 func (a *GinAnalyzer) AnalyzeDirectory(dirPath string) ([]RouteInfo, error) {
-	var routes []RouteInfo
-
 	files, err := filepath.Glob(filepath.Join(dirPath, "*.go"))
 	if err != nil {
 		return nil, err
 	}
 
+	// Todos los archivos del paquete se parsean antes, así las funciones que registran
+	// rutas sobre un grupo recibido se siguen aunque estén en otro archivo
+	var sources []sourceFile
 	for _, file := range files {
 		if strings.HasSuffix(file, "_test.go") {
 			continue
 		}
 
-		fileRoutes, err := a.AnalyzeFile(file)
+		parsed, err := parser.ParseFile(a.fset, file, nil, parser.ParseComments)
 		if err != nil {
 			return nil, err
 		}
-
-		routes = append(routes, fileRoutes...)
+		sources = append(sources, sourceFile{path: file, file: parsed})
 	}
 
-	return routes, nil
+	return a.analyzeSources(sources), nil
 }
 
 func (a *GinAnalyzer) AnalyzeFile(filePath string) ([]RouteInfo, error) {
-	var routes []RouteInfo
-
 	file, err := parser.ParseFile(a.fset, filePath, nil, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
 
-	ast.Inspect(file, func(n ast.Node) bool {
-		if callExpr, ok := n.(*ast.CallExpr); ok {
-			if route := a.analyzeCallExpression(callExpr, filePath); route != nil {
-				routes = append(routes, *route)
-			}
-		}
-		return true
-	})
-
-	return routes, nil
+	return a.analyzeSources([]sourceFile{{path: filePath, file: file}}), nil
 }
 
-func (a *GinAnalyzer) analyzeCallExpression(call *ast.CallExpr, filePath string) *RouteInfo {
+func (a *GinAnalyzer) analyzeCallExpression(scope *groupScope, call *ast.CallExpr, filePath string) *RouteInfo {
 	selector, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok {
 		return nil
@@ -90,19 +80,27 @@ func (a *GinAnalyzer) analyzeCallExpression(call *ast.CallExpr, filePath string)
 		return nil
 	}
 
-	handler := a.extractHandlerName(call.Args[1])
+	// El handler es el último argumento; los anteriores son middlewares de la ruta
+	handlerExpr := call.Args[len(call.Args)-1]
+	handler := a.extractHandlerName(handlerExpr)
 
 	pos := a.fset.Position(call.Pos())
 
+	group := a.groupFor(scope, selector.X, filePath)
 	openAPIPath := a.convertGinPathToOpenAPI(path)
 
+	var middleware []MiddlewareInfo
+	middleware = append(middleware, group.middleware...)
+	middleware = append(middleware, a.middlewareList(call.Args[1:len(call.Args)-1], filePath)...)
+
 	return &RouteInfo{
-		Method: strings.ToUpper(methodName),
-		Path: openAPIPath,
-		Handler: handler,
-		HandlerType: a.determineHandlerType(call.Args[1]),
-		File: filePath,
-		Line: pos.Line,
+		Method:      strings.ToUpper(methodName),
+		Path:        openAPIPath,
+		Handler:     handler,
+		HandlerType: a.determineHandlerType(handlerExpr),
+		File:        filePath,
+		Line:        pos.Line,
+		Middleware:  middleware,
 	}
 }
 
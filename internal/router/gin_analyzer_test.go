@@ -3,6 +3,7 @@ package router
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -82,4 +83,210 @@ func main() {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestGroupsAndMiddleware(t *testing.T) {
+	testCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	r.GET("/health", Health)
+	r.Use(Logger())
+
+	api := r.Group("/api/v1", AuthRequired())
+	{
+		users := api.Group("/users")
+		users.GET("/:id", GetUser)
+		users.DELETE("/:id", RequireRole("admin"), DeleteUser)
+	}
+	r.Group("/admin").GET("/stats", Stats)
+}
+`
+
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "main.go")
+	if err := os.WriteFile(testFile, []byte(testCode), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	routes, err := NewGinAnalyzer().AnalyzeFile(testFile)
+	if err != nil {
+		t.Fatalf("AnalyzeFile failed: %v", err)
+	}
+
+	expected := map[string]struct {
+		path       string
+		handler    string
+		middleware []string
+	}{
+		"Health":     {"/health", "Health", nil},
+		"GetUser":    {"/{id}", "GetUser", []string{"Logger", "AuthRequired"}},
+		"DeleteUser": {"/{id}", "DeleteUser", []string{"Logger", "AuthRequired", "RequireRole"}},
+		"Stats":      {"/stats", "Stats", []string{"Logger"}},
+	}
+
+	if len(routes) != len(expected) {
+		t.Fatalf("Expected %d routes, got %d", len(expected), len(routes))
+	}
+
+	for _, route := range routes {
+		want, exists := expected[route.Handler]
+		if !exists {
+			t.Errorf("Unexpected handler: %s", route.Handler)
+			continue
+		}
+		if route.Path != want.path {
+			t.Errorf("Expected path %s for %s, got %s", want.path, route.Handler, route.Path)
+		}
+
+		var names []string
+		for _, middleware := range route.Middleware {
+			names = append(names, middleware.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(want.middleware, ",") {
+			t.Errorf("Expected middleware %v for %s, got %v", want.middleware, route.Handler, names)
+		}
+	}
+}
+
+func TestGroupsPassedToHelpers(t *testing.T) {
+	mainCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+	r := gin.Default()
+	v1 := r.Group("/api/v1", AuthRequired())
+	registerUserRoutes(v1)
+
+	handler := &OrderHandler{}
+	handler.RegisterRoutes(r.Group("/shop", Session()), "unused")
+}
+`
+	routesCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+func registerUserRoutes(rg *gin.RouterGroup) {
+	users := rg.Group("/users")
+	users.Use(RateLimit())
+	users.GET("/:id", GetUser)
+}
+
+func (h *OrderHandler) RegisterRoutes(router gin.IRouter, name string) {
+	router.GET("/orders", h.ListOrders)
+}
+`
+
+	tempDir := t.TempDir()
+	for name, code := range map[string]string{"main.go": mainCode, "routes.go": routesCode} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(code), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	routes, err := NewGinAnalyzer().AnalyzeDirectory(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory failed: %v", err)
+	}
+
+	// Las rutas de los helpers se documentan una sola vez, con el grupo recibido
+	if len(routes) != 2 {
+		t.Fatalf("Expected 2 routes, got %+v", routes)
+	}
+
+	getUser := routes[0]
+	if getUser.Handler != "GetUser" || getUser.Path != "/{id}" {
+		t.Errorf("Expected GetUser at /{id}, got %s at %s", getUser.Handler, getUser.Path)
+	}
+	var names []string
+	for _, middleware := range getUser.Middleware {
+		names = append(names, middleware.Name)
+	}
+	if strings.Join(names, ",") != "AuthRequired,RateLimit" {
+		t.Errorf("Expected group middleware on GetUser, got %v", names)
+	}
+
+	listOrders := routes[1]
+	if listOrders.Handler != "h.ListOrders" || len(listOrders.Middleware) != 1 || listOrders.Middleware[0].Name != "Session" {
+		t.Errorf("Expected h.ListOrders with Session middleware, got %+v", listOrders)
+	}
+}
+
+func TestRegistrarMethodsOnDifferentReceivers(t *testing.T) {
+	mainCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type Server struct {
+	orders *OrderHandler
+}
+
+func NewUserHandler() *UserHandler {
+	return &UserHandler{}
+}
+
+func main() {
+	r := gin.Default()
+	users := NewUserHandler()
+	users.RegisterRoutes(r.Group("/users", UserAuth()))
+
+	srv := &Server{orders: &OrderHandler{}}
+	srv.orders.RegisterRoutes(r.Group("/orders", OrderAuth()))
+}
+`
+	handlersCode := `
+package main
+
+import "github.com/gin-gonic/gin"
+
+type UserHandler struct{}
+
+type OrderHandler struct{}
+
+func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id", h.GetUser)
+}
+
+func (h *OrderHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/:id", h.GetOrder)
+}
+`
+
+	tempDir := t.TempDir()
+	for name, code := range map[string]string{"main.go": mainCode, "handlers.go": handlersCode} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(code), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	routes, err := NewGinAnalyzer().AnalyzeDirectory(tempDir)
+	if err != nil {
+		t.Fatalf("AnalyzeDirectory failed: %v", err)
+	}
+
+	// Cada llamada sigue el método del tipo de su receptor, con el grupo que recibe
+	expected := map[string]string{
+		"h.GetUser":  "UserAuth",
+		"h.GetOrder": "OrderAuth",
+	}
+	if len(routes) != len(expected) {
+		t.Fatalf("Expected %d routes, got %+v", len(expected), routes)
+	}
+	for _, route := range routes {
+		want, exists := expected[route.Handler]
+		if !exists {
+			t.Errorf("Unexpected route %s", route.Handler)
+			continue
+		}
+		if len(route.Middleware) != 1 || route.Middleware[0].Name != want {
+			t.Errorf("Expected %s middleware for %s, got %+v", want, route.Handler, route.Middleware)
+		}
+	}
+}
@@ -0,0 +1,445 @@
+package router
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// MiddlewareInfo describe un middleware aplicado a una ruta, ya sea como argumento
+// de la ruta, en Group o con Use. La posición permite volver a encontrar la expresión
+// al analizarla con información de tipos.
+type MiddlewareInfo struct {
+	Name   string // gin.BasicAuth, AuthRequired, middleware.JWT
+	File   string
+	Line   int
+	Column int
+}
+
+// routeGroup son los middlewares acumulados de un *gin.RouterGroup
+type routeGroup struct {
+	middleware []MiddlewareInfo
+}
+
+// groupScope sigue las variables de grupo de una función en orden de aparición:
+// api := r.Group("/api", mw) y api.Use(mw) afectan a las rutas registradas después
+type groupScope struct {
+	groups map[string]routeGroup
+	values map[string]string // Variable → tipo del paquete, para resolver receptores
+}
+
+func newGroupScope() *groupScope {
+	return &groupScope{groups: make(map[string]routeGroup), values: make(map[string]string)}
+}
+
+// groupFor resuelve el grupo sobre el que se registra una ruta: una variable
+// conocida, una llamada encadenada r.Group("/x").GET(...) o el engine
+func (a *GinAnalyzer) groupFor(scope *groupScope, expr ast.Expr, filePath string) routeGroup {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return scope.groups[x.Name]
+	case *ast.CallExpr:
+		if selector, ok := x.Fun.(*ast.SelectorExpr); ok && selector.Sel.Name == "Group" {
+			return a.subGroup(scope, selector.X, x.Args, filePath)
+		}
+	}
+	return routeGroup{}
+}
+
+// subGroup construye el grupo resultante de parent.Group(prefix, middlewares...)
+func (a *GinAnalyzer) subGroup(scope *groupScope, parent ast.Expr, args []ast.Expr, filePath string) routeGroup {
+	base := a.groupFor(scope, parent, filePath)
+	if len(args) == 0 {
+		return base
+	}
+
+	group := routeGroup{}
+	group.middleware = append(group.middleware, base.middleware...)
+	group.middleware = append(group.middleware, a.middlewareList(args[1:], filePath)...)
+	return group
+}
+
+// trackGroups registra las asignaciones api := r.Group(...) y las llamadas api.Use(...)
+func (a *GinAnalyzer) trackGroups(scope *groupScope, n ast.Node, filePath string) {
+	switch x := n.(type) {
+	case *ast.AssignStmt:
+		if len(x.Lhs) != len(x.Rhs) {
+			return
+		}
+		for i, rhs := range x.Rhs {
+			call, ok := rhs.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || selector.Sel.Name != "Group" {
+				continue
+			}
+			if ident, ok := x.Lhs[i].(*ast.Ident); ok {
+				scope.groups[ident.Name] = a.subGroup(scope, selector.X, call.Args, filePath)
+			}
+		}
+	case *ast.CallExpr:
+		selector, ok := x.Fun.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "Use" {
+			return
+		}
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			group := scope.groups[ident.Name]
+			middleware := append([]MiddlewareInfo{}, group.middleware...)
+			group.middleware = append(middleware, a.middlewareList(x.Args, filePath)...)
+			scope.groups[ident.Name] = group
+		}
+	}
+}
+
+// middlewareList describe las expresiones de middleware de una llamada
+func (a *GinAnalyzer) middlewareList(args []ast.Expr, filePath string) []MiddlewareInfo {
+	var middleware []MiddlewareInfo
+	for _, arg := range args {
+		pos := a.fset.Position(arg.Pos())
+		middleware = append(middleware, MiddlewareInfo{
+			Name:   a.extractHandlerName(arg),
+			File:   filePath,
+			Line:   pos.Line,
+			Column: pos.Column,
+		})
+	}
+	return middleware
+}
+
+// maxRegistrarDepth limita cuántos niveles de funciones de registro se siguen
+const maxRegistrarDepth = 3
+
+// sourceFile es un archivo parseado del paquete analizado
+type sourceFile struct {
+	path string
+	file *ast.File
+}
+
+// routeRegistrar es una función que registra rutas sobre el grupo o el engine que recibe,
+// como registerUserRoutes(rg *gin.RouterGroup). routerParams tiene, por posición, el
+// nombre de los parámetros de tipo router ("" para el resto).
+type routeRegistrar struct {
+	decl         *ast.FuncDecl
+	path         string
+	routerParams []string
+}
+
+// functionRoutes son las rutas encontradas al recorrer una función por sí sola
+type functionRoutes struct {
+	decl   *ast.FuncDecl
+	routes []RouteInfo
+}
+
+// packageIndex reúne las declaraciones del paquete que permiten resolver a qué función
+// de registro llama cada expresión: h.RegisterRoutes(rg) se busca por el tipo de h
+type packageIndex struct {
+	registrars map[string]routeRegistrar    // registerUserRoutes o UserHandler.RegisterRoutes
+	results    map[string]string            // Función → tipo que devuelve (NewUserHandler → UserHandler)
+	fields     map[string]map[string]string // Tipo → campo → tipo del campo
+}
+
+// analyzeSources recorre cada función del paquete. Las llamadas a funciones de registro
+// con un grupo conocido se siguen con los middlewares de ese grupo; esas funciones ya
+// no se documentan por separado, donde el grupo sería desconocido.
+func (a *GinAnalyzer) analyzeSources(sources []sourceFile) []RouteInfo {
+	index := indexPackage(sources)
+	expanded := make(map[*ast.FuncDecl]bool)
+
+	var functions []functionRoutes
+	for _, source := range sources {
+		for _, decl := range source.file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			// Los grupos son variables locales: cada función empieza sin grupos conocidos
+			routes := a.analyzeFunction(funcDecl, source.path, newGroupScope(), index, expanded, 0)
+			functions = append(functions, functionRoutes{decl: funcDecl, routes: routes})
+		}
+	}
+
+	var routes []RouteInfo
+	for _, function := range functions {
+		if !expanded[function.decl] {
+			routes = append(routes, function.routes...)
+		}
+	}
+	return routes
+}
+
+// analyzeFunction devuelve las rutas registradas en una función con los grupos del ámbito
+func (a *GinAnalyzer) analyzeFunction(funcDecl *ast.FuncDecl, filePath string, scope *groupScope, index *packageIndex, expanded map[*ast.FuncDecl]bool, depth int) []RouteInfo {
+	index.trackParams(scope, funcDecl)
+
+	var routes []RouteInfo
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		a.trackGroups(scope, n, filePath)
+		index.trackValues(scope, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if route := a.analyzeCallExpression(scope, call, filePath); route != nil {
+			routes = append(routes, *route)
+			return true
+		}
+
+		// registerUserRoutes(v1) o h.RegisterRoutes(r.Group("/users"))
+		registrar, exists := index.registrarFor(scope, call)
+		if !exists || registrar.decl == funcDecl || depth >= maxRegistrarDepth {
+			return true
+		}
+		if registrarScope, ok := a.registrarScope(scope, registrar, call, filePath); ok {
+			expanded[registrar.decl] = true
+			routes = append(routes, a.analyzeFunction(registrar.decl, registrar.path, registrarScope, index, expanded, depth+1)...)
+		}
+		return true
+	})
+	return routes
+}
+
+// registrarScope asocia los parámetros router de la función de registro con los grupos
+// pasados como argumento
+func (a *GinAnalyzer) registrarScope(scope *groupScope, registrar routeRegistrar, call *ast.CallExpr, filePath string) (*groupScope, bool) {
+	if len(call.Args) != len(registrar.routerParams) {
+		return nil, false
+	}
+
+	registrarScope := newGroupScope()
+	found := false
+	for i, arg := range call.Args {
+		if name := registrar.routerParams[i]; name != "" {
+			registrarScope.groups[name] = a.groupFor(scope, arg, filePath)
+			found = true
+		}
+	}
+	return registrarScope, found
+}
+
+// indexPackage busca las funciones y métodos con algún parámetro de tipo router, los
+// tipos que devuelven las funciones y los campos de los structs del paquete
+func indexPackage(sources []sourceFile) *packageIndex {
+	index := &packageIndex{
+		registrars: make(map[string]routeRegistrar),
+		results:    make(map[string]string),
+		fields:     make(map[string]map[string]string),
+	}
+
+	for _, source := range sources {
+		for _, decl := range source.file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				index.addFunction(decl, source.path)
+			case *ast.GenDecl:
+				index.addStructs(decl)
+			}
+		}
+	}
+	return index
+}
+
+// addFunction registra el tipo que devuelve una función y, si recibe un router, la
+// función de registro con la clave de registrarKey
+func (index *packageIndex) addFunction(funcDecl *ast.FuncDecl, filePath string) {
+	if funcDecl.Recv == nil && funcDecl.Type.Results != nil && len(funcDecl.Type.Results.List) > 0 {
+		if result := typeName(funcDecl.Type.Results.List[0].Type); result != "" {
+			index.results[funcDecl.Name.Name] = result
+		}
+	}
+	if funcDecl.Body == nil {
+		return
+	}
+
+	var params []string
+	hasRouter := false
+	for _, field := range funcDecl.Type.Params.List {
+		router := isRouterType(field.Type)
+		if len(field.Names) == 0 {
+			params = append(params, "") // Sin nombre: el cuerpo no puede usarlo
+			continue
+		}
+		for _, ident := range field.Names {
+			name := ""
+			if router {
+				name, hasRouter = ident.Name, true
+			}
+			params = append(params, name)
+		}
+	}
+
+	if hasRouter {
+		index.registrars[registrarKey(funcDecl)] = routeRegistrar{decl: funcDecl, path: filePath, routerParams: params}
+	}
+}
+
+// addStructs registra los tipos de los campos de los structs declarados
+func (index *packageIndex) addStructs(decl *ast.GenDecl) {
+	if decl.Tok != token.TYPE {
+		return
+	}
+	for _, spec := range decl.Specs {
+		typeSpec := spec.(*ast.TypeSpec)
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+
+		fields := make(map[string]string)
+		for _, field := range structType.Fields.List {
+			fieldType := typeName(field.Type)
+			if len(field.Names) == 0 {
+				fields[fieldType] = fieldType // Campo embebido
+			}
+			for _, ident := range field.Names {
+				fields[ident.Name] = fieldType
+			}
+		}
+		index.fields[typeSpec.Name.Name] = fields
+	}
+}
+
+// trackParams recuerda el tipo del receptor y de los parámetros de la función
+func (index *packageIndex) trackParams(scope *groupScope, funcDecl *ast.FuncDecl) {
+	var fields []*ast.Field
+	if funcDecl.Recv != nil {
+		fields = append(fields, funcDecl.Recv.List...)
+	}
+	fields = append(fields, funcDecl.Type.Params.List...)
+
+	for _, field := range fields {
+		for _, ident := range field.Names {
+			if fieldType := typeName(field.Type); fieldType != "" {
+				scope.values[ident.Name] = fieldType
+			}
+		}
+	}
+}
+
+// trackValues recuerda el tipo de las variables asignadas o declaradas en la función:
+// h := &UserHandler{}, h := NewUserHandler(db) o var h UserHandler
+func (index *packageIndex) trackValues(scope *groupScope, n ast.Node) {
+	switch x := n.(type) {
+	case *ast.AssignStmt:
+		if len(x.Lhs) != len(x.Rhs) {
+			return
+		}
+		for i, lhs := range x.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				scope.values[ident.Name] = index.typeOf(scope, x.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		for i, ident := range x.Names {
+			switch {
+			case x.Type != nil:
+				scope.values[ident.Name] = typeName(x.Type)
+			case i < len(x.Values):
+				scope.values[ident.Name] = index.typeOf(scope, x.Values[i])
+			}
+		}
+	}
+}
+
+// typeOf resuelve el tipo del paquete de una expresión ("" si no se conoce)
+func (index *packageIndex) typeOf(scope *groupScope, expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return scope.values[x.Name]
+	case *ast.ParenExpr:
+		return index.typeOf(scope, x.X)
+	case *ast.StarExpr:
+		return index.typeOf(scope, x.X)
+	case *ast.UnaryExpr:
+		if x.Op == token.AND {
+			return index.typeOf(scope, x.X)
+		}
+	case *ast.CompositeLit:
+		return typeName(x.Type)
+	case *ast.CallExpr:
+		if ident, ok := x.Fun.(*ast.Ident); ok {
+			if ident.Name == "new" && len(x.Args) == 1 {
+				return typeName(x.Args[0])
+			}
+			return index.results[ident.Name]
+		}
+	case *ast.SelectorExpr:
+		if base := index.typeOf(scope, x.X); base != "" {
+			return index.fields[base][x.Sel.Name]
+		}
+	}
+	return ""
+}
+
+// registrarFor busca la función de registro invocada. Los métodos se buscan por el tipo
+// del receptor; si no se puede resolver, solo se sigue cuando un único tipo declara el método.
+func (index *packageIndex) registrarFor(scope *groupScope, call *ast.CallExpr) (routeRegistrar, bool) {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		registrar, exists := index.registrars[fun.Name]
+		return registrar, exists
+	case *ast.SelectorExpr:
+		if receiver := index.typeOf(scope, fun.X); receiver != "" {
+			registrar, exists := index.registrars[receiver+"."+fun.Sel.Name]
+			return registrar, exists
+		}
+
+		var found routeRegistrar
+		matches := 0
+		for key, registrar := range index.registrars {
+			if strings.HasSuffix(key, "."+fun.Sel.Name) {
+				found = registrar
+				matches++
+			}
+		}
+		return found, matches == 1
+	}
+	return routeRegistrar{}, false
+}
+
+// registrarKey identifica una función por su nombre y un método por el tipo del receptor
+// y su nombre (UserHandler.RegisterRoutes)
+func registrarKey(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	return typeName(funcDecl.Recv.List[0].Type) + "." + funcDecl.Name.Name
+}
+
+// typeName devuelve el nombre de un tipo declarado en el paquete, sin puntero ni
+// argumentos genéricos ("" para tipos de otros paquetes o sin nombre)
+func typeName(expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.StarExpr:
+		return typeName(x.X)
+	case *ast.IndexExpr:
+		return typeName(x.X)
+	case *ast.IndexListExpr:
+		return typeName(x.X)
+	}
+	return ""
+}
+
+// isRouterType reconoce *gin.RouterGroup, *gin.Engine, gin.IRouter y gin.IRoutes
+func isRouterType(expr ast.Expr) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || ident.Name != "gin" {
+		return false
+	}
+
+	switch selector.Sel.Name {
+	case "RouterGroup", "Engine", "IRouter", "IRoutes":
+		return true
+	}
+	return false
+}